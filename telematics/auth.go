@@ -0,0 +1,205 @@
+package telematics
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/boiledgas/protocol/telematics/section"
+)
+
+var (
+	ErrNotAuthenticated     = errors.New("not authenticated")
+	ErrAuthenticationFailed = errors.New("authentication failed")
+)
+
+// Authenticator verifies authentication section of request and returns device identity
+type Authenticator interface {
+	Authenticate(s *Session, r *Request) (string, error)
+}
+
+type AuthenticatorFunc func(s *Session, r *Request) (string, error)
+
+func (f AuthenticatorFunc) Authenticate(s *Session, r *Request) (string, error) {
+	return f(s, r)
+}
+
+// Challenger is authenticator sending nonce in responses to session until it is authenticated
+type Challenger interface {
+	Authenticator
+	Challenge(s *Session) ([]byte, error)
+}
+
+// SecretStore returns secret of device identifier
+type SecretStore interface {
+	Lookup(identifier string) ([]byte, bool)
+}
+
+func credentials(auth *section.Authentication) (string, []byte, error) {
+	if !auth.Has(section.AUTHENTICATION_FLAGS_IDENTIFIER) || !auth.Has(section.AUTHENTICATION_FLAGS_SECRET) {
+		return "", nil, fmt.Errorf("identifier and secret required: %w", ErrAuthenticationFailed)
+	}
+	return auth.Identifier, auth.Secret, nil
+}
+
+// StaticAuthenticator compares secret with secret stored for identifier
+type StaticAuthenticator struct {
+	Secrets map[string][]byte
+}
+
+// LoadStaticAuthenticator reads file of "identifier secret" lines, see ReadStaticAuthenticator
+func LoadStaticAuthenticator(path string) (*StaticAuthenticator, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadStaticAuthenticator(f)
+}
+
+// ReadStaticAuthenticator reads "identifier secret" lines, secret prefixed with "hex:" is hex encoded,
+// empty lines and lines starting with # are skipped
+func ReadStaticAuthenticator(r io.Reader) (*StaticAuthenticator, error) {
+	a := StaticAuthenticator{Secrets: make(map[string][]byte)}
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %v: identifier and secret expected", n)
+		}
+		secret := []byte(fields[1])
+		if strings.HasPrefix(fields[1], "hex:") {
+			var err error
+			if secret, err = hex.DecodeString(strings.TrimPrefix(fields[1], "hex:")); err != nil {
+				return nil, fmt.Errorf("line %v: %v", n, err)
+			}
+		}
+		if _, ok := a.Secrets[fields[0]]; ok {
+			return nil, fmt.Errorf("line %v: identifier %v duplicated", n, fields[0])
+		}
+		a.Secrets[fields[0]] = secret
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (a *StaticAuthenticator) Lookup(identifier string) ([]byte, bool) {
+	secret, ok := a.Secrets[identifier]
+	return secret, ok
+}
+
+func (a *StaticAuthenticator) Authenticate(s *Session, r *Request) (string, error) {
+	identifier, secret, err := credentials(&r.Auth)
+	if err != nil {
+		return "", err
+	}
+	expected, ok := a.Lookup(identifier)
+	if !ok || !hmac.Equal(expected, secret) {
+		return "", fmt.Errorf("%v: %w", identifier, ErrAuthenticationFailed)
+	}
+	return identifier, nil
+}
+
+// HMACAuthenticator accepts secret equal to HMAC-SHA256 of identifier with Key,
+// device secrets are derived from one server key and need no per device storage
+type HMACAuthenticator struct {
+	Key []byte
+}
+
+// Secret returns secret of device with identifier
+func (a *HMACAuthenticator) Secret(identifier string) []byte {
+	mac := hmac.New(sha256.New, a.Key)
+	mac.Write([]byte(identifier))
+	return mac.Sum(nil)
+}
+
+func (a *HMACAuthenticator) Lookup(identifier string) ([]byte, bool) {
+	return a.Secret(identifier), true
+}
+
+func (a *HMACAuthenticator) Authenticate(s *Session, r *Request) (string, error) {
+	identifier, secret, err := credentials(&r.Auth)
+	if err != nil {
+		return "", err
+	}
+	if !hmac.Equal(a.Secret(identifier), secret) {
+		return "", fmt.Errorf("%v: %w", identifier, ErrAuthenticationFailed)
+	}
+	return identifier, nil
+}
+
+// ChallengeAuthenticator accepts digest of nonce sent in last response instead of secret,
+// nonce is used once and secret is never sent by device
+type ChallengeAuthenticator struct {
+	Store SecretStore
+	Rand  io.Reader // source of nonces, crypto/rand if nil
+}
+
+const NONCE_SIZE = 16
+
+func (a *ChallengeAuthenticator) Challenge(s *Session) ([]byte, error) {
+	random := a.Rand
+	if random == nil {
+		random = rand.Reader
+	}
+	nonce := make([]byte, NONCE_SIZE)
+	if _, err := io.ReadFull(random, nonce); err != nil {
+		return nil, err
+	}
+	s.Nonce = nonce
+	return nonce, nil
+}
+
+func (a *ChallengeAuthenticator) Authenticate(s *Session, r *Request) (string, error) {
+	auth := &r.Auth
+	nonce := s.Nonce
+	s.Nonce = nil
+	if !auth.Has(section.AUTHENTICATION_FLAGS_IDENTIFIER) || !auth.Has(section.AUTHENTICATION_FLAGS_DIGEST) {
+		return "", fmt.Errorf("identifier and digest required: %w", ErrAuthenticationFailed)
+	}
+	if auth.Has(section.AUTHENTICATION_FLAGS_SECRET) {
+		return "", fmt.Errorf("%v: secret not accepted: %w", auth.Identifier, ErrAuthenticationFailed)
+	}
+	if nonce == nil || auth.Has(section.AUTHENTICATION_FLAGS_NONCE) && !bytes.Equal(auth.Nonce, nonce) {
+		return "", fmt.Errorf("%v: nonce not valid: %w", auth.Identifier, ErrAuthenticationFailed)
+	}
+	secret, ok := a.Store.Lookup(auth.Identifier)
+	if !ok || !hmac.Equal(ChallengeDigest(secret, nonce, auth.Identifier, r.Sequence), auth.Digest) {
+		return "", fmt.Errorf("%v: %w", auth.Identifier, ErrAuthenticationFailed)
+	}
+	return auth.Identifier, nil
+}
+
+// ChallengeDigest returns HMAC-SHA256 of nonce, identifier and request sequence keyed with secret
+func ChallengeDigest(secret []byte, nonce []byte, identifier string, sequence byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(nonce)
+	mac.Write([]byte(identifier))
+	mac.Write([]byte{sequence})
+	return mac.Sum(nil)
+}
+
+// ChallengeAuthentication returns authentication section of device answering nonce in request with sequence
+func ChallengeAuthentication(identifier string, secret []byte, nonce []byte, sequence byte) (auth section.Authentication) {
+	auth.Identifier = identifier
+	auth.Nonce = nonce
+	auth.Digest = ChallengeDigest(secret, nonce, identifier, sequence)
+	auth.Set(section.AUTHENTICATION_FLAGS_IDENTIFIER, true)
+	auth.Set(section.AUTHENTICATION_FLAGS_NONCE, true)
+	auth.Set(section.AUTHENTICATION_FLAGS_DIGEST, true)
+	return
+}
@@ -0,0 +1,184 @@
+package telematics
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boiledgas/protocol/telematics/section"
+	"github.com/boiledgas/protocol/telematics/value"
+)
+
+func authRequest(identifier string, secret []byte) Request {
+	r := Request{Sequence: Sequence()}
+	r.Auth = section.Authentication{Identifier: identifier, Secret: secret}
+	r.Auth.Set(section.AUTHENTICATION_FLAGS_IDENTIFIER, true)
+	r.Auth.Set(section.AUTHENTICATION_FLAGS_SECRET, true)
+	r.Set(section.FLAG_AUTHENTICATION, true)
+	return r
+}
+
+func Test_StaticAuthenticator(t *testing.T) {
+	a, err := ReadStaticAuthenticator(strings.NewReader("# devices\ndev1 secret1\n\ndev2 hex:0102ff\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []struct {
+		identifier string
+		secret     []byte
+		ok         bool
+	}{
+		{"dev1", []byte("secret1"), true},
+		{"dev2", []byte{1, 2, 0xff}, true},
+		{"dev1", []byte("secret2"), false},
+		{"dev3", []byte("secret1"), false},
+	} {
+		r := authRequest(c.identifier, c.secret)
+		device, err := a.Authenticate(nil, &r)
+		if c.ok && (err != nil || device != c.identifier) {
+			t.Errorf("%v not authenticated: %v", c.identifier, err)
+		}
+		if !c.ok && !errors.Is(err, ErrAuthenticationFailed) {
+			t.Errorf("%v authenticated: %v", c.identifier, err)
+		}
+	}
+	if _, err = ReadStaticAuthenticator(strings.NewReader("dev1\n")); err == nil {
+		t.Error("line without secret read")
+	}
+
+	h := HMACAuthenticator{Key: []byte("key")}
+	r := authRequest("dev1", h.Secret("dev1"))
+	if device, err := h.Authenticate(nil, &r); err != nil || device != "dev1" {
+		t.Errorf("hmac secret not accepted: %v", err)
+	}
+	r = authRequest("dev2", h.Secret("dev1"))
+	if _, err := h.Authenticate(nil, &r); !errors.Is(err, ErrAuthenticationFailed) {
+		t.Errorf("hmac secret of other device accepted: %v", err)
+	}
+}
+
+func Test_ServerAuthentication(t *testing.T) {
+	speed := section.ModuleProperty{ModuleId: 3, Id: 2, Type: value.Speed, Name: "speed"}
+	conf := Configuration{Modules: []section.Module{{Id: 3, Name: "tracker"}}, Properties: []section.ModuleProperty{speed}}
+	tracker := NewTracker(NewMemoryCommandStore(), time.Minute)
+	tracker.Queue("dev1", section.CommandExecute{ModuleId: 3, CommandId: 1})
+	handled := make(chan string, 1)
+	srv := Server{
+		Authenticator: &StaticAuthenticator{Secrets: map[string][]byte{"dev1": []byte("secret")}},
+		Tracker:       tracker,
+		State:         NewDeviceState(),
+		Handler: HandlerFunc(func(s *Session, r *Request) Response {
+			if r.Has(section.FLAG_MODULE_PROPERTY_VALUE) {
+				handled <- s.Device
+			}
+			return Response{Flags: RESPONSE_OK}
+		}),
+	}
+	device, conn := net.Pipe()
+	defer device.Close()
+	go srv.ServeConn(conn)
+
+	w := NewWriter(device)
+	w.Configuration = &conf
+	reader := NewReader(device)
+	reader.Configuration = &conf
+	send := func(r Request) Packet {
+		if err := w.WriteRequest(&r); err != nil {
+			t.Fatal(err)
+		}
+		p := Packet{}
+		if err := reader.Read(&p); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+
+	confRequest := Request{Sequence: Sequence(), Conf: conf}
+	confRequest.Set(section.FLAG_MODULE, true)
+	confRequest.Set(section.FLAG_MODULE_PROPERTY, true)
+	if p := send(confRequest); p.Response.Flags != RESPONSE_OK {
+		t.Errorf("configuration rejected: %v", p.Response.Flags)
+	}
+
+	values := Request{Sequence: Sequence(), Values: []section.ModulePropertyValue{{ModuleId: 3, Values: map[byte]interface{}{2: float32(10)}, Types: map[byte]value.DataType{}}}}
+	values.Set(section.FLAG_MODULE_PROPERTY_VALUE, true)
+	if p := send(values); !p.Response.Has(RESPONSE_AUTHORIZATION | RESPONSE_ERROR) {
+		t.Errorf("values accepted before authentication: %v", p.Response.Flags)
+	}
+	if p := send(authRequest("dev1", []byte("wrong"))); !p.Response.Has(RESPONSE_AUTHORIZATION|RESPONSE_ERROR) || p.Response.Sequence == 0 {
+		t.Errorf("wrong secret accepted: %v", p.Response)
+	}
+	auth := authRequest("dev1", []byte("secret"))
+	if p := send(auth); p.Response.Flags != RESPONSE_OK || p.Response.Sequence != auth.Sequence {
+		t.Errorf("authentication failed: %v", p.Response)
+	}
+	if snapshot, ok := srv.State.Get("dev1"); !ok || !snapshot.Connected {
+		t.Errorf("authenticated device not connected: %v", snapshot)
+	}
+	p := Packet{}
+	if err := reader.Read(&p); err != nil || len(p.Request.Executes) != 1 {
+		t.Fatalf("queued command not delivered: %v %v", p, err)
+	}
+
+	if p := send(values); p.Response.Flags != RESPONSE_OK {
+		t.Errorf("values rejected after authentication: %v", p.Response.Flags)
+	}
+	if device := <-handled; device != "dev1" {
+		t.Errorf("session device wrong: %v", device)
+	}
+}
+
+func Test_ChallengeAuthentication(t *testing.T) {
+	static := &StaticAuthenticator{Secrets: map[string][]byte{"dev1": []byte("secret")}}
+	srv := Server{Authenticator: &ChallengeAuthenticator{Store: static}}
+	connect := func() (func(r Request) Response, func()) {
+		device, conn := net.Pipe()
+		go srv.ServeConn(conn)
+		w, reader := NewWriter(device), NewReader(device)
+		return func(r Request) Response {
+			if err := w.WriteRequest(&r); err != nil {
+				t.Fatal(err)
+			}
+			p := Packet{}
+			if err := reader.Read(&p); err != nil {
+				t.Fatal(err)
+			}
+			return p.Response
+		}, func() { device.Close() }
+	}
+	hello := Request{Sequence: Sequence(), Id: section.Identification{CodeText: "dev1"}}
+	hello.Id.Set(section.IDENTIFICATION_FLAGS_CODETEXT, true)
+	hello.Set(section.FLAG_IDENTIFICATION, true)
+
+	send, done := connect()
+	defer done()
+	resp := send(hello)
+	if !resp.Has(RESPONSE_CHALLENGE) || len(resp.Nonce) != NONCE_SIZE {
+		t.Fatalf("challenge not sent: %v", resp)
+	}
+	auth := Request{Sequence: Sequence()}
+	auth.Auth = ChallengeAuthentication("dev1", []byte("wrong"), resp.Nonce, auth.Sequence)
+	auth.Set(section.FLAG_AUTHENTICATION, true)
+	resp = send(auth)
+	if !resp.Has(RESPONSE_AUTHORIZATION|RESPONSE_ERROR) || !resp.Has(RESPONSE_CHALLENGE) {
+		t.Fatalf("wrong secret accepted: %v", resp)
+	}
+	auth.Sequence = Sequence()
+	auth.Auth = ChallengeAuthentication("dev1", []byte("secret"), resp.Nonce, auth.Sequence)
+	if resp = send(auth); resp.Flags != RESPONSE_OK || resp.Nonce != nil {
+		t.Fatalf("authentication failed: %v", resp)
+	}
+
+	replay, closeReplay := connect()
+	defer closeReplay()
+	replay(hello)
+	if resp = replay(auth); !resp.Has(RESPONSE_AUTHORIZATION | RESPONSE_ERROR) {
+		t.Errorf("replayed authentication accepted: %v", resp)
+	}
+	plain := authRequest("dev1", []byte("secret"))
+	if resp = replay(plain); !resp.Has(RESPONSE_AUTHORIZATION | RESPONSE_ERROR) {
+		t.Errorf("secret accepted in challenge mode: %v", resp)
+	}
+}
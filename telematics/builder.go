@@ -0,0 +1,87 @@
+package telematics
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/boiledgas/protocol/telematics/section"
+)
+
+// FieldError is error of one command argument or property value, Kind is "argument" or "property"
+type FieldError struct {
+	Kind string
+	Name string
+	Id   byte
+	Err  error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%v %v (%v): %v", e.Kind, e.Name, e.Id, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// BuildError lists errors of all invalid fields of command or property write
+type BuildError struct {
+	Target string
+	Fields []*FieldError
+}
+
+func (e *BuildError) Error() string {
+	var buf bytes.Buffer
+	buf.WriteString(e.Target)
+	buf.WriteString(":")
+	for _, f := range e.Fields {
+		buf.WriteString(" ")
+		buf.WriteString(f.Error())
+		buf.WriteString(";")
+	}
+	return buf.String()
+}
+
+func (e *BuildError) add(kind string, name string, id byte, err error) {
+	e.Fields = append(e.Fields, &FieldError{Kind: kind, Name: name, Id: id, Err: err})
+}
+
+func (e *BuildError) err() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
+
+// builder collects values by name, module and fields are resolved from configuration on build
+type builder struct {
+	Configuration *Configuration
+	Module        string
+	Values        map[string]interface{}
+}
+
+func newBuilder(conf *Configuration, module string) builder {
+	return builder{Configuration: conf, Module: module, Values: make(map[string]interface{})}
+}
+
+func (b *builder) module(target string) (m section.Module, err error) {
+	if b.Configuration == nil {
+		err = fmt.Errorf("%v: configuration not set", target)
+		return
+	}
+	var ok bool
+	if m, ok = b.Configuration.ModuleByName(b.Module); !ok {
+		err = fmt.Errorf("module %v not found", b.Module)
+	}
+	return
+}
+
+// names returns names of values in sorted order
+func (b *builder) names() []string {
+	names := make([]string, 0, len(b.Values))
+	for name := range b.Values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
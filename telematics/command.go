@@ -0,0 +1,95 @@
+package telematics
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/boiledgas/protocol/telematics/section"
+	"github.com/boiledgas/protocol/telematics/value"
+)
+
+var (
+	ErrArgumentRequired = errors.New("required argument not set")
+	ErrArgumentUnknown  = errors.New("argument not defined")
+)
+
+// Command builds command execution, module, command and arguments are resolved by name from configuration
+type Command struct {
+	builder
+	Command string
+}
+
+func NewCommand(conf *Configuration, module string, command string) *Command {
+	return &Command{builder: newBuilder(conf, module), Command: command}
+}
+
+func (c *Command) Arg(name string, v interface{}) *Command {
+	c.Values[name] = v
+	return c
+}
+
+// Execute validates arguments and returns command execute section, argument errors are returned as *BuildError
+func (c *Command) Execute() (s section.CommandExecute, err error) {
+	target := fmt.Sprintf("command %v.%v", c.Module, c.Command)
+	module, err := c.module(target)
+	if err != nil {
+		return
+	}
+	command, ok := c.Configuration.CommandByName(module.Id, c.Command)
+	if !ok {
+		err = fmt.Errorf("command %v.%v not found", c.Module, c.Command)
+		return
+	}
+
+	s = section.CommandExecute{
+		ModuleId:  module.Id,
+		CommandId: command.Id,
+		Arguments: make(map[byte]interface{}),
+		Types:     make(map[byte]value.DataType),
+	}
+	buildErr := BuildError{Target: target}
+	known := make(map[string]bool)
+	for _, arg := range c.Configuration.Arguments {
+		if arg.ModuleId != module.Id || arg.CommandId != command.Id {
+			continue
+		}
+		name := arg.Name
+		if name == "" {
+			name = fmt.Sprintf("%v", arg.Id)
+		}
+		known[name] = true
+		v, ok := c.Values[name]
+		if !ok {
+			if arg.IsRequired() {
+				buildErr.add("argument", name, arg.Id, ErrArgumentRequired)
+			}
+			continue
+		}
+		if v, err = arg.Check(v); err != nil {
+			buildErr.add("argument", name, arg.Id, err)
+			err = nil
+			continue
+		}
+		s.Arguments[arg.Id] = v
+		s.Types[arg.Id] = arg.Type
+	}
+	for _, name := range c.names() {
+		if !known[name] {
+			buildErr.add("argument", name, 0, ErrArgumentUnknown)
+		}
+	}
+	err = buildErr.err()
+	return
+}
+
+// Request returns request with single command execute section
+func (c *Command) Request() (r Request, err error) {
+	var s section.CommandExecute
+	if s, err = c.Execute(); err != nil {
+		return
+	}
+	r.Sequence = Sequence()
+	r.Executes = []section.CommandExecute{s}
+	r.Set(section.FLAG_COMMAND_EXECUTE, true)
+	return
+}
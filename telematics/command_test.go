@@ -0,0 +1,67 @@
+package telematics
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/boiledgas/protocol/telematics/section"
+)
+
+func Test_CommandBuilder(t *testing.T) {
+	conf := testConfiguration()
+	req, err := NewCommand(&conf, "engine", "block").Arg("delay", 30).Arg("mode", 2).Request()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !req.Has(section.FLAG_COMMAND_EXECUTE) || len(req.Executes) != 1 {
+		t.Fatalf("request wrong: %v", req)
+	}
+	e := req.Executes[0]
+	if e.ModuleId != 2 || e.CommandId != 5 || e.Arguments[1] != uint16(30) || e.Arguments[2] != byte(2) {
+		t.Errorf("command execute wrong: %v", e)
+	}
+
+	buf := bytes.Buffer{}
+	w := NewWriter(&buf)
+	w.Configuration = &conf
+	w.WriteRequest(&req)
+	r := NewReader(&buf)
+	r.Configuration = &conf
+	res := Request{}
+	if err := r.ReadRequest(&res); err != nil {
+		t.Fatal(err)
+	}
+	if res.Executes[0].Arguments[1] != uint16(30) {
+		t.Errorf("wire command wrong: %v", res.Executes[0])
+	}
+}
+
+func Test_CommandErrors(t *testing.T) {
+	conf := testConfiguration()
+	_, err := NewCommand(&conf, "engine", "block").Arg("delay", 601).Arg("speed", 1).Execute()
+	cmdErr, ok := err.(*BuildError)
+	if !ok {
+		t.Fatalf("command error expected: %v", err)
+	}
+	if len(cmdErr.Fields) != 3 {
+		t.Fatalf("argument errors wrong: %v", cmdErr)
+	}
+	if !errors.Is(cmdErr.Fields[0], section.ErrAboveMax) || cmdErr.Fields[0].Name != "delay" || cmdErr.Fields[0].Kind != "argument" {
+		t.Errorf("max error wrong: %v", cmdErr.Fields[0])
+	}
+	if !errors.Is(cmdErr.Fields[1], ErrArgumentRequired) || cmdErr.Fields[1].Id != 2 {
+		t.Errorf("required error wrong: %v", cmdErr.Fields[1])
+	}
+	if !errors.Is(cmdErr.Fields[2], ErrArgumentUnknown) {
+		t.Errorf("unknown error wrong: %v", cmdErr.Fields[2])
+	}
+
+	_, err = NewCommand(&conf, "engine", "block").Arg("mode", 3).Execute()
+	if err == nil || !errors.Is(err.(*BuildError).Fields[0], section.ErrNotInList) {
+		t.Errorf("list error wrong: %v", err)
+	}
+	if _, err = NewCommand(&conf, "engine", "start").Execute(); err == nil {
+		t.Error("unknown command not detected")
+	}
+}
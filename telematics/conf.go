@@ -14,6 +14,45 @@ type Configuration struct {
 	Arguments  []section.CommandArgument
 }
 
+func (c *Configuration) GetModule(moduleId byte, module *section.Module) (ok bool) {
+	ok = false
+	for _, m := range c.Modules {
+		if m.Id == moduleId {
+			*module = m
+			ok = true
+			break
+		}
+	}
+	return
+}
+
+func (c *Configuration) ModuleByName(name string) (m section.Module, ok bool) {
+	for _, m = range c.Modules {
+		if m.Name == name {
+			return m, true
+		}
+	}
+	return section.Module{}, false
+}
+
+func (c *Configuration) CommandByName(moduleId byte, name string) (cmd section.Command, ok bool) {
+	for _, cmd = range c.Commands {
+		if cmd.ModuleId == moduleId && cmd.Name == name {
+			return cmd, true
+		}
+	}
+	return section.Command{}, false
+}
+
+func (c *Configuration) PropertyByName(moduleId byte, name string) (p section.ModuleProperty, ok bool) {
+	for _, p = range c.Properties {
+		if p.ModuleId == moduleId && p.Name == name {
+			return p, true
+		}
+	}
+	return section.ModuleProperty{}, false
+}
+
 func (c *Configuration) GetProperty(moduleId byte, propertyId byte, property *section.ModuleProperty) (ok bool) {
 	ok = false
 	for _, p := range c.Properties {
@@ -26,6 +65,15 @@ func (c *Configuration) GetProperty(moduleId byte, propertyId byte, property *se
 	return
 }
 
+// Label returns list label of property value
+func (c *Configuration) Label(moduleId byte, propertyId byte, v interface{}) (string, bool) {
+	var p section.ModuleProperty
+	if !c.GetProperty(moduleId, propertyId, &p) {
+		return "", false
+	}
+	return p.Label(v)
+}
+
 func (c *Configuration) GetArgument(moduleId byte, commandId byte, argumentId byte, argument *section.CommandArgument) (ok bool) {
 	ok = false
 	for _, arg := range c.Arguments {
@@ -0,0 +1,67 @@
+package telematics
+
+import (
+	"time"
+
+	"github.com/boiledgas/protocol/telematics/section"
+	"github.com/boiledgas/protocol/telematics/value"
+)
+
+// testConfiguration is configuration shared by tests: module 1 "sensors" with read only values,
+// module 2 "engine" with command "block" and module 3 "tracker" with writable properties
+func testConfiguration() Configuration {
+	sensors := section.Module{Id: 1, Name: "sensors"}
+	sensors.Set(section.MODULE_FLAGS_NAME, true)
+	position := section.ModuleProperty{ModuleId: 1, Id: 1, Type: value.GPS, Name: "position"}
+	position.Set(section.MODULE_PROPERTY_FLAGS_NAME, true)
+	temperature := section.ModuleProperty{ModuleId: 1, Id: 2, Type: value.Temperature, Min: float32(-40), Max: float32(85), Access: section.PROPERTYACCESS_READ}
+	temperature.Set(section.MODULE_PROPERTY_FLAGS_MIN, true)
+	temperature.Set(section.MODULE_PROPERTY_FLAGS_MAX, true)
+	temperature.Set(section.MODULE_PROPERTY_FLAGS_ACCESS, true)
+	state := section.ModuleProperty{ModuleId: 1, Id: 3, Type: value.Byte, List: []value.NameValue{{Name: "Parked", Value: byte(1)}, {Name: "Driving", Value: byte(3)}}}
+	state.Set(section.MODULE_PROPERTY_FLAGS_LIST, true)
+	uptime := section.ModuleProperty{ModuleId: 1, Id: 4, Type: value.Timespan}
+	reboot := section.Command{ModuleId: 1, Id: 1, Name: "reboot"}
+	reboot.Set(section.COMMAND_FLAGS_NAME, true)
+	reason := section.CommandArgument{ModuleId: 1, CommandId: 1, Id: 1, Type: value.Byte, Required: 1}
+	reason.Set(section.COMMAND_ARGUMENT_FLAGS_REQUIRED, true)
+
+	engine := section.Module{Id: 2, Name: "engine"}
+	engine.Set(section.MODULE_FLAGS_NAME, true)
+	block := section.Command{ModuleId: 2, Id: 5, Name: "block"}
+	block.Set(section.COMMAND_FLAGS_NAME, true)
+	delay := section.CommandArgument{ModuleId: 2, CommandId: 5, Id: 1, Type: value.UShort, Min: uint16(0), Max: uint16(600), Name: "delay"}
+	delay.Set(section.COMMAND_ARGUMENT_FLAGS_MIN, true)
+	delay.Set(section.COMMAND_ARGUMENT_FLAGS_MAX, true)
+	delay.Set(section.COMMAND_ARGUMENT_FLAGS_NAME, true)
+	mode := section.CommandArgument{ModuleId: 2, CommandId: 5, Id: 2, Type: value.Byte, Required: 1, Name: "mode"}
+	mode.List = []value.NameValue{{Name: "Soft", Value: byte(1)}, {Name: "Hard", Value: byte(2)}}
+	mode.Set(section.COMMAND_ARGUMENT_FLAGS_LIST, true)
+	mode.Set(section.COMMAND_ARGUMENT_FLAGS_REQUIRED, true)
+	mode.Set(section.COMMAND_ARGUMENT_FLAGS_NAME, true)
+
+	tracker := section.Module{Id: 3, Name: "tracker"}
+	tracker.Set(section.MODULE_FLAGS_NAME, true)
+	interval := section.ModuleProperty{ModuleId: 3, Id: 1, Type: value.Timespan, Min: 10 * time.Second, Max: time.Hour, Access: section.PROPERTYACCESS_READ | section.PROPERTYACCESS_CONFIG, Name: "interval"}
+	interval.Set(section.MODULE_PROPERTY_FLAGS_NAME, true)
+	interval.Set(section.MODULE_PROPERTY_FLAGS_MIN, true)
+	interval.Set(section.MODULE_PROPERTY_FLAGS_MAX, true)
+	interval.Set(section.MODULE_PROPERTY_FLAGS_ACCESS, true)
+	speed := section.ModuleProperty{ModuleId: 3, Id: 2, Type: value.Speed, Access: section.PROPERTYACCESS_READ, Name: "speed"}
+	speed.Set(section.MODULE_PROPERTY_FLAGS_NAME, true)
+	speed.Set(section.MODULE_PROPERTY_FLAGS_ACCESS, true)
+	motion := section.ModuleProperty{ModuleId: 3, Id: 3, Type: value.Byte, Name: "motion", List: []value.NameValue{{Name: "Parked", Value: byte(1)}, {Name: "Driving", Value: byte(3)}}}
+	motion.Set(section.MODULE_PROPERTY_FLAGS_NAME, true)
+	motion.Set(section.MODULE_PROPERTY_FLAGS_LIST, true)
+	secret := section.ModuleProperty{ModuleId: 3, Id: 4, Type: value.Byte, Access: section.PROPERTYACCESS_WRITE, Name: "secret"}
+	secret.Set(section.MODULE_PROPERTY_FLAGS_NAME, true)
+	secret.Set(section.MODULE_PROPERTY_FLAGS_ACCESS, true)
+
+	return Configuration{
+		Hash:       7,
+		Modules:    []section.Module{sensors, engine, tracker},
+		Properties: []section.ModuleProperty{position, temperature, state, uptime, interval, speed, motion, secret},
+		Commands:   []section.Command{reboot, block},
+		Arguments:  []section.CommandArgument{reason, delay, mode},
+	}
+}
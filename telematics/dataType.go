@@ -4,9 +4,28 @@ import (
 	"encoding/binary"
 	"github.com/boiledgas/protocol/telematics/value"
 	"github.com/boiledgas/protocol/utils"
+	"math"
 	"time"
 )
 
+// fixed-point wire formats of scaled data types
+var (
+	fixedAnalog      = utils.FixedPoint{Scale: 1000.0, Min: 0, Max: math.MaxUint32}
+	fixedTemperature = utils.FixedPoint{Scale: 10.0, Min: math.MinInt16, Max: math.MaxInt16}
+	fixedHumidity    = utils.FixedPoint{Scale: 10.0, Min: 0, Max: math.MaxUint16}
+	fixedPressure    = utils.FixedPoint{Scale: 0.01, Min: 0, Max: math.MaxUint16}
+	fixedWeight      = utils.FixedPoint{Scale: 1000.0, Min: 0, Max: math.MaxUint16}
+	fixedAngle       = utils.FixedPoint{Scale: 100.0, Min: 0, Max: math.MaxUint16}
+	fixedSpeed       = utils.FixedPoint{Scale: 10.0, Min: 0, Max: math.MaxUint16}
+	fixedMileage     = utils.FixedPoint{Scale: 1000.0, Min: 0, Max: math.MaxUint32}
+	fixedRpm         = utils.FixedPoint{Scale: 0.1, Min: math.MinInt16, Max: math.MaxInt16}
+	fixedDistance    = utils.FixedPoint{Scale: 1000.0, Min: 0, Max: math.MaxUint32}
+	fixedCoordinate  = utils.FixedPoint{Scale: 10000000.0, Min: math.MinInt32, Max: math.MaxInt32}
+	fixedGpsPrecise  = utils.FixedPoint{Scale: 100.0, Min: 0, Max: math.MaxUint16}
+	fixedAxis        = utils.FixedPoint{Scale: 1000.0, Min: math.MinInt16, Max: math.MaxInt16}
+	fixedGyro        = utils.FixedPoint{Scale: 10.0, Min: math.MinInt16, Max: math.MaxInt16}
+)
+
 func (r *TelematicsReader) readData(t value.DataType) interface{} {
 	switch t {
 	case value.Bool:
@@ -163,7 +182,7 @@ func (r *TelematicsReader) readData(t value.DataType) interface{} {
 	case value.Rpm:
 		var v int16
 		binary.Read(r.reader, binary.LittleEndian, &v)
-		return int32(v * 10.0)
+		return int32(v) * 10
 	case value.EngineHours:
 		var v uint32
 		r.ReadUInt24(&v)
@@ -231,8 +250,7 @@ func (r *TelematicsReader) readData(t value.DataType) interface{} {
 		return v
 	case value.IOPort:
 		v := value.IoPort{}
-		binary.Read(r.reader, binary.LittleEndian, &v.Flags)
-		binary.Read(r.reader, binary.LittleEndian, &v.State)
+		r.ReadIoPort(&v)
 		return v
 	case value.GPS:
 		v := value.Gps{}
@@ -242,6 +260,10 @@ func (r *TelematicsReader) readData(t value.DataType) interface{} {
 		v := value.Gsm{}
 		r.ReadGsm(&v)
 		return v
+	case value.CELLINFO:
+		v := value.CellInfo{}
+		r.ReadCellInfo(&v)
+		return v
 	case value.ACCELERATION:
 		v := value.Acceleration{}
 		r.ReadAcceleration(&v)
@@ -255,7 +277,14 @@ func (r *TelematicsReader) readData(t value.DataType) interface{} {
 	panic("not implemented dataType")
 }
 
-func (w *TelematicsWriter) WriteData(v interface{}, t value.DataType) {
+// WriteData writes value of data type, value not representable by data type is returned as error
+func (w *TelematicsWriter) WriteData(v interface{}, t value.DataType) (err error) {
+	defer recoverWrite(&err)
+	w.writeData(v, t)
+	return
+}
+
+func (w *TelematicsWriter) writeData(v interface{}, t value.DataType) {
 	switch t {
 	case value.Bool:
 		w.WriteBool(v.(bool))
@@ -314,7 +343,7 @@ func (w *TelematicsWriter) WriteData(v interface{}, t value.DataType) {
 	case value.Frequency:
 		binary.Write(w.Writer, binary.LittleEndian, v.(uint32))
 	case value.Analog:
-		analog := uint32(v.(float64) * 1000)
+		analog := uint32(w.fixed(fixedAnalog, v.(float64)))
 		binary.Write(w.Writer, binary.LittleEndian, analog)
 	case value.Timestamp:
 		timestamp := uint32(v.(time.Time).Unix())
@@ -323,128 +352,78 @@ func (w *TelematicsWriter) WriteData(v interface{}, t value.DataType) {
 		timespan := uint32(v.(time.Duration).Seconds())
 		binary.Write(w.Writer, binary.LittleEndian, timespan)
 	case value.Temperature:
-		temperature := int16(v.(float32) * 10.0)
+		temperature := int16(w.fixed(fixedTemperature, float64(v.(float32))))
 		binary.Write(w.Writer, binary.LittleEndian, temperature)
 	case value.Humidity:
-		humidity := uint16(v.(float32) * 10.0)
+		humidity := uint16(w.fixed(fixedHumidity, float64(v.(float32))))
 		binary.Write(w.Writer, binary.LittleEndian, humidity)
 	case value.Pressure:
-		pressure := uint16(v.(float32) / 100.0)
+		pressure := uint16(w.fixed(fixedPressure, float64(v.(float32))))
 		binary.Write(w.Writer, binary.LittleEndian, pressure)
 	case value.Weight:
-		weight := uint16(v.(float32) * 1000.0)
+		weight := uint16(w.fixed(fixedWeight, float64(v.(float32))))
 		binary.Write(w.Writer, binary.LittleEndian, weight)
 	case value.Loudness:
 		binary.Write(w.Writer, binary.LittleEndian, v.(byte))
 	case value.Angle:
-		//77.77 - bad value must be 7777 but it 7776!
-		angle := uint16(utils.Round(float64(v.(float32) * 100.0)))
+		angle := uint16(w.fixed(fixedAngle, float64(v.(float32))))
 		binary.Write(w.Writer, binary.LittleEndian, angle)
 	case value.Speed:
-		speed := uint16(v.(float32) * 10.0)
+		speed := uint16(w.fixed(fixedSpeed, float64(v.(float32))))
 		binary.Write(w.Writer, binary.LittleEndian, speed)
 	case value.Mileage:
-		mileage := uint32(v.(float64) * 1000.0)
+		mileage := uint32(w.fixed(fixedMileage, v.(float64)))
 		binary.Write(w.Writer, binary.LittleEndian, mileage)
 	case value.Rpm:
-		rpm := v.(int32) / 10.0
+		rpm := int16(w.fixed(fixedRpm, float64(v.(int32))))
 		binary.Write(w.Writer, binary.LittleEndian, rpm)
 	case value.EngineHours:
 		w.WriteUInt24(v.(uint32))
 	case value.Distance:
-		distance := uint32(v.(float64) * 1000.0)
+		distance := uint32(w.fixed(fixedDistance, v.(float64)))
 		binary.Write(w.Writer, binary.LittleEndian, distance)
 	case value.COMMON:
-		value := v.(value.Common)
-		w.WriteCommon(&value)
+		val := v.(value.Common)
+		w.writeCommon(&val, 1.0, 1.0)
 	case value.Voltage:
 		val := v.(value.Common)
-		if voltage_value, ok := val.Value, val.Has(value.COMMON_FLAG_VALUE); ok {
-			val.Value = voltage_value * 1000.0
-		}
-		if value_meter, ok := val.Meter, val.Has(value.COMMON_FLAG_METER); ok {
-			val.Value = value_meter * 1000.0
-		}
-		w.WriteCommon(&val)
+		w.writeCommon(&val, 1000.0, 1000.0)
 	case value.Battery:
 		val := v.(value.Common)
-		if voltage_value, ok := val.Value, val.Has(value.COMMON_FLAG_VALUE); ok {
-			val.Value = voltage_value * 1000.0
-		}
-		if value_meter, ok := val.Meter, val.Has(value.COMMON_FLAG_METER); ok {
-			val.Value = value_meter * 1000.0
-		}
-		w.WriteCommon(&val)
+		w.writeCommon(&val, 1000.0, 1000.0)
 	case value.Power:
 		val := v.(value.Common)
-		if voltage_value, ok := val.Value, val.Has(value.COMMON_FLAG_VALUE); ok {
-			val.Value = voltage_value * 1000.0
-		}
-		if value_meter, ok := val.Meter, val.Has(value.COMMON_FLAG_METER); ok {
-			val.Value = value_meter * 1000.0
-		}
-		w.WriteCommon(&val)
+		w.writeCommon(&val, 1000.0, 1000.0)
 	case value.Liquid:
 		val := v.(value.Common)
-		if voltage_value, ok := val.Value, val.Has(value.COMMON_FLAG_VALUE); ok {
-			val.Value = voltage_value * 1000.0
-		}
-		if value_meter, ok := val.Meter, val.Has(value.COMMON_FLAG_METER); ok {
-			val.Value = value_meter * 10.0
-		}
-		w.WriteCommon(&val)
+		w.writeCommon(&val, 1000.0, 10.0)
 	case value.Water:
 		val := v.(value.Common)
-		if voltage_value, ok := val.Value, val.Has(value.COMMON_FLAG_VALUE); ok {
-			val.Value = voltage_value * 1000.0
-		}
-		if value_meter, ok := val.Meter, val.Has(value.COMMON_FLAG_METER); ok {
-			val.Value = value_meter * 10.0
-		}
-		w.WriteCommon(&val)
+		w.writeCommon(&val, 1000.0, 10.0)
 	case value.Fuel:
 		val := v.(value.Common)
-		if voltage_value, ok := val.Value, val.Has(value.COMMON_FLAG_VALUE); ok {
-			val.Value = voltage_value * 1000.0
-		}
-		if value_meter, ok := val.Meter, val.Has(value.COMMON_FLAG_METER); ok {
-			val.Value = value_meter * 10.0
-		}
-		w.WriteCommon(&val)
+		w.writeCommon(&val, 1000.0, 10.0)
 	case value.Gas:
 		val := v.(value.Common)
-		if voltage_value, ok := val.Value, val.Has(value.COMMON_FLAG_VALUE); ok {
-			val.Value = voltage_value * 1000.0
-		}
-		if value_meter, ok := val.Meter, val.Has(value.COMMON_FLAG_METER); ok {
-			val.Value = value_meter * 1000.0
-		}
-		w.WriteCommon(&val)
+		w.writeCommon(&val, 1000.0, 1000.0)
 	case value.Illuminance:
 		val := v.(value.Common)
-		if voltage_value, ok := val.Value, val.Has(value.COMMON_FLAG_VALUE); ok {
-			val.Value = voltage_value * 100.0
-		}
-		w.WriteCommon(&val)
+		w.writeCommon(&val, 100.0, 1.0)
 	case value.Radiation:
 		val := v.(value.Common)
-		if voltage_value, ok := val.Value, val.Has(value.COMMON_FLAG_VALUE); ok {
-			val.Value = voltage_value * 10.0
-		}
-		if value_meter, ok := val.Meter, val.Has(value.COMMON_FLAG_METER); ok {
-			val.Value = value_meter * 100.0
-		}
-		w.WriteCommon(&val)
+		w.writeCommon(&val, 10.0, 100.0)
 	case value.IOPort:
-		value := v.(value.IoPort)
-		binary.Write(w.Writer, binary.LittleEndian, value.Flags)
-		binary.Write(w.Writer, binary.LittleEndian, value.State)
+		port := v.(value.IoPort)
+		w.WriteIoPort(&port)
 	case value.GPS:
 		gps := v.(value.Gps)
 		w.WriteGps(&gps)
 	case value.GSM:
 		gsm := v.(value.Gsm)
 		w.WriteGsm(&gsm)
+	case value.CELLINFO:
+		cell := v.(value.CellInfo)
+		w.WriteCellInfo(&cell)
 	case value.ACCELERATION:
 		acc := v.(value.Acceleration)
 		w.WriteAcceleration(&acc)
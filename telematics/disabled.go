@@ -0,0 +1,168 @@
+package telematics
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/boiledgas/protocol/telematics/section"
+)
+
+var ErrPropertyDisabled = errors.New("property disabled")
+
+// DisabledProperties is set of properties device must not report, kept per device session.
+// Request with property disabled sections carries whole set, one empty section enables all properties
+type DisabledProperties struct {
+	mutex sync.RWMutex
+	set   map[section.PropertyRef]bool
+}
+
+func NewDisabledProperties() *DisabledProperties {
+	return &DisabledProperties{set: make(map[section.PropertyRef]bool)}
+}
+
+// Init disables properties with PROPERTYACCESS_DISABLED access in configuration
+func (d *DisabledProperties) Init(conf *Configuration) {
+	for _, p := range conf.Properties {
+		if p.HasAccess(section.PROPERTYACCESS_DISABLED) {
+			d.Disable(p.ModuleId, p.Id)
+		}
+	}
+}
+
+func (d *DisabledProperties) Disable(moduleId byte, propertyId byte) {
+	d.mutex.Lock()
+	if d.set == nil {
+		d.set = make(map[section.PropertyRef]bool)
+	}
+	d.set[section.PropertyRef{ModuleId: moduleId, PropertyId: propertyId}] = true
+	d.mutex.Unlock()
+}
+
+func (d *DisabledProperties) Enable(moduleId byte, propertyId byte) {
+	d.mutex.Lock()
+	delete(d.set, section.PropertyRef{ModuleId: moduleId, PropertyId: propertyId})
+	d.mutex.Unlock()
+}
+
+func (d *DisabledProperties) IsDisabled(moduleId byte, propertyId byte) bool {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	return d.set[section.PropertyRef{ModuleId: moduleId, PropertyId: propertyId}]
+}
+
+// SetByName enables or disables property resolved by names from configuration
+func (d *DisabledProperties) SetByName(conf *Configuration, module string, property string, disabled bool) error {
+	m, ok := conf.ModuleByName(module)
+	if !ok {
+		return fmt.Errorf("module %v not found", module)
+	}
+	p, ok := conf.PropertyByName(m.Id, property)
+	if !ok {
+		return fmt.Errorf("property %v.%v: %w", module, property, ErrPropertyUnknown)
+	}
+	if disabled {
+		d.Disable(m.Id, p.Id)
+	} else {
+		d.Enable(m.Id, p.Id)
+	}
+	return nil
+}
+
+// Properties returns disabled properties ordered by module and property id
+func (d *DisabledProperties) Properties() []section.PropertyRef {
+	d.mutex.RLock()
+	res := make([]section.PropertyRef, 0, len(d.set))
+	for p := range d.set {
+		res = append(res, p)
+	}
+	d.mutex.RUnlock()
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].ModuleId != res[j].ModuleId {
+			return res[i].ModuleId < res[j].ModuleId
+		}
+		return res[i].PropertyId < res[j].PropertyId
+	})
+	return res
+}
+
+// Sections returns whole set as sections, each module appears once per section
+func (d *DisabledProperties) Sections() (sections []section.ModulePropertyDisable) {
+	for _, p := range d.Properties() {
+		i := 0
+		for ; i < len(sections); i++ {
+			if _, ok := sections[i].DisabledProperties[p.ModuleId]; !ok {
+				break
+			}
+		}
+		if i == len(sections) {
+			sections = append(sections, section.ModulePropertyDisable{DisabledProperties: make(map[byte]byte)})
+		}
+		sections[i].DisabledProperties[p.ModuleId] = p.PropertyId
+	}
+	if len(sections) == 0 {
+		sections = append(sections, section.ModulePropertyDisable{DisabledProperties: make(map[byte]byte)})
+	}
+	return
+}
+
+// Load replaces set with properties of sections
+func (d *DisabledProperties) Load(sections []section.ModulePropertyDisable) {
+	set := make(map[section.PropertyRef]bool)
+	for _, s := range sections {
+		for _, p := range s.Properties() {
+			set[p] = true
+		}
+	}
+	d.mutex.Lock()
+	d.set = set
+	d.mutex.Unlock()
+}
+
+// Request returns request sending disabled set to device
+func (d *DisabledProperties) Request() (r Request) {
+	r.Sequence = Sequence()
+	r.Disabled = d.Sections()
+	r.Set(section.FLAG_MODULE_PROPERTY_DISABLED, true)
+	return
+}
+
+// Check returns error listing values of disabled properties in request
+func (d *DisabledProperties) Check(r *Request) error {
+	var disabled []section.PropertyRef
+	for _, s := range r.Values {
+		for id := range s.Values {
+			if d.IsDisabled(s.ModuleId, id) {
+				disabled = append(disabled, section.PropertyRef{ModuleId: s.ModuleId, PropertyId: id})
+			}
+		}
+	}
+	if len(disabled) == 0 {
+		return nil
+	}
+	sort.Slice(disabled, func(i, j int) bool { return disabled[i].String() < disabled[j].String() })
+	return fmt.Errorf("%v: %w", disabled, ErrPropertyDisabled)
+}
+
+// Filter removes values of disabled properties from request and returns count of removed values
+func (d *DisabledProperties) Filter(r *Request) (dropped int) {
+	values := r.Values[:0]
+	for _, s := range r.Values {
+		for id := range s.Values {
+			if d.IsDisabled(s.ModuleId, id) {
+				delete(s.Values, id)
+				delete(s.Types, id)
+				dropped++
+			}
+		}
+		if len(s.Values) > 0 {
+			values = append(values, s)
+		}
+	}
+	r.Values = values
+	if dropped > 0 && len(values) == 0 {
+		r.Set(section.FLAG_MODULE_PROPERTY_VALUE, false)
+	}
+	return
+}
@@ -0,0 +1,179 @@
+package telematics
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/boiledgas/protocol/telematics/section"
+	"github.com/boiledgas/protocol/telematics/value"
+)
+
+func Test_DisabledPropertiesRoundTrip(t *testing.T) {
+	conf := testConfiguration()
+	d := NewDisabledProperties()
+	d.Disable(3, 1)
+	d.Disable(3, 2)
+	d.Disable(4, 7)
+	if err := d.SetByName(&conf, "tracker", "speed", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.SetByName(&conf, "tracker", "mode", true); !errors.Is(err, ErrPropertyUnknown) {
+		t.Errorf("unknown property error wrong: %v", err)
+	}
+
+	req := d.Request()
+	buf := bytes.Buffer{}
+	w := NewWriter(&buf)
+	w.Configuration = &conf
+	w.WriteRequest(&req)
+	r := NewReader(&buf)
+	r.Configuration = &conf
+	res := Request{}
+	if err := r.ReadRequest(&res); err != nil {
+		t.Fatal(err)
+	}
+	device := NewDisabledProperties()
+	device.Load(res.Disabled)
+	expected := []section.PropertyRef{{ModuleId: 3, PropertyId: 1}, {ModuleId: 4, PropertyId: 7}}
+	if !reflect.DeepEqual(device.Properties(), expected) {
+		t.Errorf("disabled wrong: %v != %v", device.Properties(), expected)
+	}
+
+	// several properties of one module need several sections
+	d.Disable(3, 2)
+	if sections := d.Sections(); len(sections) != 2 {
+		t.Errorf("sections wrong: %v", sections)
+	}
+	// empty set is one empty section
+	all := NewDisabledProperties()
+	if sections := all.Sections(); len(sections) != 1 || len(sections[0].DisabledProperties) != 0 {
+		t.Errorf("empty sections wrong: %v", sections)
+	}
+}
+
+func Test_DisabledPropertiesFilter(t *testing.T) {
+	conf := testConfiguration()
+	conf.Properties[5].Access |= section.PROPERTYACCESS_DISABLED // tracker speed
+	d := NewDisabledProperties()
+	d.Init(&conf)
+	if !d.IsDisabled(3, 2) || d.IsDisabled(3, 1) {
+		t.Fatalf("init wrong: %v", d.Properties())
+	}
+
+	req := Request{}
+	req.Values = []section.ModulePropertyValue{
+		{ModuleId: 3, Values: map[byte]interface{}{1: int64(60), 2: float32(50)}, Types: map[byte]value.DataType{1: value.Timespan, 2: value.Speed}},
+		{ModuleId: 3, Values: map[byte]interface{}{2: float32(51)}},
+	}
+	req.Set(section.FLAG_MODULE_PROPERTY_VALUE, true)
+	if err := d.Check(&req); !errors.Is(err, ErrPropertyDisabled) {
+		t.Errorf("check error wrong: %v", err)
+	}
+	if dropped := d.Filter(&req); dropped != 2 {
+		t.Errorf("dropped wrong: %v", dropped)
+	}
+	if len(req.Values) != 1 || len(req.Values[0].Values) != 1 || !req.Has(section.FLAG_MODULE_PROPERTY_VALUE) {
+		t.Errorf("filtered request wrong: %v", req.Values)
+	}
+	if err := d.Check(&req); err != nil {
+		t.Errorf("filtered request rejected: %v", err)
+	}
+}
+
+func Test_ServerDropsDisabledValues(t *testing.T) {
+	conf := Configuration{Modules: []section.Module{{Id: 3}}, Properties: []section.ModuleProperty{{ModuleId: 3, Id: 1, Type: value.Timespan}, {ModuleId: 3, Id: 2, Type: value.Speed}}}
+	handled := make(chan Request, 1)
+	srv := Server{
+		State: NewDeviceState(),
+		Handler: HandlerFunc(func(s *Session, r *Request) Response {
+			if r.Has(section.FLAG_MODULE_PROPERTY_VALUE) {
+				handled <- *r
+			}
+			return Response{Flags: RESPONSE_OK}
+		}),
+	}
+	device, conn := net.Pipe()
+	defer device.Close()
+	go srv.ServeConn(conn)
+
+	w := NewWriter(device)
+	w.Configuration = &conf
+	reader := NewReader(device)
+	reader.Configuration = &conf
+	send := func(r Request) {
+		if err := w.WriteRequest(&r); err != nil {
+			t.Fatal(err)
+		}
+		p := Packet{}
+		if err := reader.Read(&p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	hello := Request{Sequence: Sequence(), Id: section.Identification{Code: 42}, Conf: conf}
+	hello.Id.Set(section.IDENTIFICATION_FLAGS_CODE, true)
+	hello.Disabled = []section.ModulePropertyDisable{{DisabledProperties: map[byte]byte{3: 2}}}
+	for _, flag := range []uint16{section.FLAG_IDENTIFICATION, section.FLAG_MODULE, section.FLAG_MODULE_PROPERTY, section.FLAG_MODULE_PROPERTY_DISABLED} {
+		hello.Set(flag, true)
+	}
+	send(hello)
+	values := Request{Sequence: Sequence(), Values: []section.ModulePropertyValue{{ModuleId: 3, Values: map[byte]interface{}{1: time.Minute, 2: float32(50)}}}}
+	values.Set(section.FLAG_MODULE_PROPERTY_VALUE, true)
+	send(values)
+
+	r := <-handled
+	if len(r.Values) != 1 || len(r.Values[0].Values) != 1 || r.Values[0].Values[1] != time.Minute {
+		t.Errorf("handled values wrong: %v", r.Values)
+	}
+	if _, ok := srv.State.Value("42", 3, 2); ok {
+		t.Error("value of disabled property stored")
+	}
+	if _, ok := srv.State.Value("42", 3, 1); !ok {
+		t.Error("value not stored")
+	}
+}
+
+func Test_ServerDisablesByConfiguration(t *testing.T) {
+	conf := Configuration{Modules: []section.Module{{Id: 3}}, Properties: []section.ModuleProperty{{ModuleId: 3, Id: 1, Type: value.Timespan}, {ModuleId: 3, Id: 2, Type: value.Speed, Access: section.PROPERTYACCESS_READ | section.PROPERTYACCESS_DISABLED}}}
+	conf.Properties[1].Set(section.MODULE_PROPERTY_FLAGS_ACCESS, true)
+	srv := Server{State: NewDeviceState()}
+	device, conn := net.Pipe()
+	defer device.Close()
+	go srv.ServeConn(conn)
+
+	w := NewWriter(device)
+	w.Configuration = &conf
+	reader := NewReader(device)
+	reader.Configuration = &conf
+	send := func(r Request) {
+		if err := w.WriteRequest(&r); err != nil {
+			t.Fatal(err)
+		}
+		p := Packet{}
+		if err := reader.Read(&p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	hello := Request{Sequence: Sequence(), Id: section.Identification{Code: 42}, Conf: conf}
+	hello.Id.Set(section.IDENTIFICATION_FLAGS_CODE, true)
+	for _, flag := range []uint16{section.FLAG_IDENTIFICATION, section.FLAG_MODULE, section.FLAG_MODULE_PROPERTY} {
+		hello.Set(flag, true)
+	}
+	send(hello)
+	values := Request{Sequence: Sequence(), Values: []section.ModulePropertyValue{{ModuleId: 3, Values: map[byte]interface{}{1: time.Minute, 2: float32(50)}}}}
+	values.Set(section.FLAG_MODULE_PROPERTY_VALUE, true)
+	send(values)
+
+	if _, ok := srv.State.Value("42", 3, 2); ok {
+		t.Error("value of property disabled by configuration stored")
+	}
+	if _, ok := srv.State.Value("42", 3, 1); !ok {
+		t.Error("value not stored")
+	}
+	if s := srv.Sessions(); len(s) != 1 || !s[0].Disabled.IsDisabled(3, 2) {
+		t.Errorf("disabled properties wrong: %v", s)
+	}
+}
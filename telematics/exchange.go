@@ -0,0 +1,88 @@
+package telematics
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+var (
+	ErrResponseTimeout = errors.New("response timeout")
+	ErrStreamBroken    = errors.New("stream out of sync") // packet was read partially
+)
+
+// Exchange sends requests over connection and waits for responses
+type Exchange struct {
+	Conn          io.ReadWriter
+	Configuration *Configuration
+	Timeout       time.Duration   // response timeout, used if Conn has SetReadDeadline
+	Handler       func(p *Packet) // receives packets read while waiting for response
+
+	reader *TelematicsReader
+	broken bool // packet was read partially, next packet can not be found
+}
+
+type readDeadliner interface {
+	SetReadDeadline(t time.Time) error
+}
+
+// Send writes request and reads packets until response with request sequence.
+// Exchange returns ErrStreamBroken after error in the middle of packet, connection must be closed
+func (e *Exchange) Send(r *Request) (resp Response, err error) {
+	if e.broken {
+		return resp, ErrStreamBroken
+	}
+	if e.reader == nil {
+		e.reader = NewReader(e.Conn)
+	}
+	start := e.reader.counter.count()
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("exchange: %v", rec)
+		}
+		if err != nil && e.reader.counter.count() != start {
+			e.broken = true
+		}
+	}()
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.Configuration = e.Configuration
+	if err = w.WriteRequest(r); err != nil {
+		return
+	}
+	if _, err = e.Conn.Write(buf.Bytes()); err != nil {
+		return
+	}
+
+	if d, ok := e.Conn.(readDeadliner); ok && e.Timeout > 0 {
+		if err = d.SetReadDeadline(time.Now().Add(e.Timeout)); err != nil {
+			return
+		}
+		defer d.SetReadDeadline(time.Time{})
+	}
+	e.reader.Configuration = e.Configuration
+	for {
+		p := Packet{}
+		start = e.reader.counter.count()
+		if err = e.reader.Read(&p); err != nil {
+			if isTimeout(err) {
+				err = ErrResponseTimeout
+			}
+			return
+		}
+		if p.Has(FLAG_RESPONSE) && p.Response.Sequence == r.Sequence {
+			return p.Response, nil
+		}
+		if e.Handler != nil {
+			e.Handler(&p)
+		}
+	}
+}
+
+func isTimeout(err error) bool {
+	var t interface{ Timeout() bool }
+	return errors.As(err, &t) && t.Timeout()
+}
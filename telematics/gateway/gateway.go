@@ -0,0 +1,245 @@
+package gateway
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/boiledgas/protocol/telematics"
+	"github.com/boiledgas/protocol/telematics/section"
+	"github.com/boiledgas/protocol/telematics/value"
+)
+
+// Gateway is HTTP handler of device sessions served by Server:
+//
+//	GET  /devices                      connected devices
+//	GET  /devices/{id}                 identification of device
+//	GET  /devices/{id}/configuration   modules, properties, commands and arguments
+//	GET  /devices/{id}/values          last value of each property kept in server State
+//	POST /devices/{id}/commands        queue command, body is CommandBody
+//
+// Device which is not connected is served from snapshot of server State, commands are queued
+// for it if server has tracker. Gateway has no authentication or authorization,
+// it must be wrapped by handler which checks access of client before it is served
+type Gateway struct {
+	Server *telematics.Server
+}
+
+// New returns gateway of server, server State must be set before server is started
+// to serve devices which are not connected
+func New(srv *telematics.Server) *Gateway {
+	return &Gateway{Server: srv}
+}
+
+type DeviceBody struct {
+	Device         string                  `json:"device"`
+	Connected      bool                    `json:"connected"`
+	Authenticated  bool                    `json:"authenticated"`
+	Seen           time.Time               `json:"seen"`
+	Identification *section.Identification `json:"identification,omitempty"`
+}
+
+type ValueBody struct {
+	ModuleId   byte            `json:"moduleId"`
+	Module     string          `json:"module,omitempty"`
+	PropertyId byte            `json:"propertyId"`
+	Property   string          `json:"property,omitempty"`
+	Type       value.DataType  `json:"type"`
+	Value      json.RawMessage `json:"value"`
+	Label      string          `json:"label,omitempty"`
+	Time       time.Time       `json:"time"`
+}
+
+// CommandBody is command resolved by names from configuration of device
+type CommandBody struct {
+	Module    string                 `json:"module"`
+	Command   string                 `json:"command"`
+	Arguments map[string]interface{} `json:"arguments,omitempty"`
+}
+
+// CommandResult has id of command queued in tracker or sequence of request sent if server has no tracker
+type CommandResult struct {
+	Id       uint64 `json:"id,omitempty"`
+	Sequence byte   `json:"sequence,omitempty"`
+}
+
+type errorBody struct {
+	Error string `json:"error"`
+}
+
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.EscapedPath(), "/"), "/")
+	if parts[0] != "devices" || len(parts) > 3 {
+		writeError(w, http.StatusNotFound, errors.New("not found"))
+		return
+	}
+	if len(parts) == 1 {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+			return
+		}
+		g.devices(w)
+		return
+	}
+	device, err := url.PathUnescape(parts[1])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	resource := ""
+	if len(parts) == 3 {
+		resource = parts[2]
+	}
+	method := http.MethodGet
+	if resource == "commands" {
+		method = http.MethodPost
+	}
+	if r.Method != method {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+	if resource == "commands" {
+		g.command(w, r, device)
+		return
+	}
+	s, connected := g.Server.Session(device)
+	snapshot, known := g.snapshot(device)
+	if !connected && !known {
+		writeError(w, http.StatusNotFound, fmt.Errorf("device %v not known", device))
+		return
+	}
+	switch resource {
+	case "":
+		body := DeviceBody{Device: device, Seen: snapshot.Seen, Identification: &snapshot.Identification}
+		if connected {
+			state := s.State()
+			body = DeviceBody{Device: device, Connected: true, Authenticated: state.Authenticated, Seen: state.Seen, Identification: &state.Identification}
+		}
+		writeJSON(w, http.StatusOK, body)
+	case "configuration":
+		conf, _ := g.Server.Configuration(device)
+		writeJSON(w, http.StatusOK, conf)
+	case "values":
+		g.values(w, device, &snapshot)
+	default:
+		writeError(w, http.StatusNotFound, errors.New("not found"))
+	}
+}
+
+// snapshot returns state of device kept in server State
+func (g *Gateway) snapshot(device string) (snapshot telematics.DeviceSnapshot, ok bool) {
+	if g.Server.State == nil {
+		return
+	}
+	return g.Server.State.Get(device)
+}
+
+func (g *Gateway) devices(w http.ResponseWriter) {
+	devices := make([]DeviceBody, 0)
+	for _, s := range g.Server.Sessions() {
+		state := s.State()
+		if state.Device == "" {
+			continue
+		}
+		devices = append(devices, DeviceBody{Device: state.Device, Connected: true, Authenticated: state.Authenticated, Seen: state.Seen})
+	}
+	writeJSON(w, http.StatusOK, devices)
+}
+
+// values writes values of snapshot, names and labels are resolved by configuration of device
+func (g *Gateway) values(w http.ResponseWriter, device string, snapshot *telematics.DeviceSnapshot) {
+	values := make([]ValueBody, 0, len(snapshot.Values))
+	conf, _ := g.Server.Configuration(device)
+	for _, v := range snapshot.Values {
+		data, err := value.MarshalData(v.Type, v.Value)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		body := ValueBody{ModuleId: v.ModuleId, PropertyId: v.PropertyId, Type: v.Type, Value: data, Time: v.Time}
+		var m section.Module
+		if conf.GetModule(v.ModuleId, &m) {
+			body.Module = m.Name
+		}
+		var p section.ModuleProperty
+		if conf.GetProperty(v.ModuleId, v.PropertyId, &p) {
+			body.Property = p.Name
+		}
+		body.Label, _ = conf.Label(v.ModuleId, v.PropertyId, v.Value)
+		values = append(values, body)
+	}
+	writeJSON(w, http.StatusOK, values)
+}
+
+// command queues command in tracker and delivers it if device is connected,
+// without tracker command is sent to session of device
+func (g *Gateway) command(w http.ResponseWriter, r *http.Request, device string) {
+	conf, ok := g.Server.Configuration(device)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("device %v not known", device))
+		return
+	}
+	var body CommandBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	c := telematics.NewCommand(&conf, body.Module, body.Command)
+	for name, v := range body.Arguments {
+		c.Arg(name, v)
+	}
+	e, err := c.Execute()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	s, connected := g.Server.Session(device)
+	if tracker := g.Server.Tracker; tracker != nil {
+		id, err := tracker.Queue(device, e)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		if connected {
+			if err = g.Server.Deliver(s); err != nil { // command stays queued
+				g.logf("gateway: %v: %v", device, err)
+			}
+		}
+		writeJSON(w, http.StatusAccepted, CommandResult{Id: id})
+		return
+	}
+	if !connected {
+		writeError(w, http.StatusNotFound, fmt.Errorf("device %v not connected", device))
+		return
+	}
+	req := telematics.Request{Sequence: telematics.Sequence(), Executes: []section.CommandExecute{e}}
+	req.Set(section.FLAG_COMMAND_EXECUTE, true)
+	if err = s.Send(&req); err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, CommandResult{Sequence: req.Sequence})
+}
+
+func (g *Gateway) logf(format string, args ...interface{}) {
+	if g.Server.ErrorLog != nil {
+		g.Server.ErrorLog.Printf(format, args...)
+	} else {
+		log.Printf(format, args...)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorBody{Error: err.Error()})
+}
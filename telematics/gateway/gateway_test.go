@@ -0,0 +1,161 @@
+package gateway
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boiledgas/protocol/telematics"
+	"github.com/boiledgas/protocol/telematics/section"
+	"github.com/boiledgas/protocol/telematics/value"
+)
+
+func Test_Gateway(t *testing.T) {
+	conf := telematics.Configuration{
+		Modules:    []section.Module{{Id: 3, Name: "tracker"}},
+		Properties: []section.ModuleProperty{{ModuleId: 3, Id: 2, Type: value.Speed, Name: "speed"}},
+		Commands:   []section.Command{{ModuleId: 3, Id: 1, Name: "reboot"}},
+		Arguments:  []section.CommandArgument{{ModuleId: 3, CommandId: 1, Id: 1, Type: value.UShort, Name: "delay"}},
+	}
+	conf.Modules[0].Set(section.MODULE_FLAGS_NAME, true)
+	conf.Properties[0].Set(section.MODULE_PROPERTY_FLAGS_NAME, true)
+	conf.Commands[0].Set(section.COMMAND_FLAGS_NAME, true)
+	conf.Arguments[0].Set(section.COMMAND_ARGUMENT_FLAGS_NAME, true)
+	srv := &telematics.Server{Tracker: telematics.NewTracker(telematics.NewMemoryCommandStore(), time.Minute), State: telematics.NewDeviceState()}
+	device, conn := net.Pipe()
+	defer device.Close()
+	done := make(chan error)
+	go func() { done <- srv.ServeConn(conn) }()
+
+	w := telematics.NewWriter(device)
+	w.Configuration = &conf
+	reader := telematics.NewReader(device)
+	reader.Configuration = &conf
+	send := func(r telematics.Request) {
+		if err := w.WriteRequest(&r); err != nil {
+			t.Fatal(err)
+		}
+		p := telematics.Packet{}
+		if err := reader.Read(&p); err != nil || !p.Has(telematics.FLAG_RESPONSE) {
+			t.Fatalf("response not read: %v", err)
+		}
+	}
+	hello := telematics.Request{Sequence: telematics.Sequence(), Id: section.Identification{CodeText: "dev 1"}, Conf: conf}
+	hello.Id.Set(section.IDENTIFICATION_FLAGS_CODETEXT, true)
+	for _, flag := range []uint16{section.FLAG_IDENTIFICATION, section.FLAG_MODULE, section.FLAG_MODULE_PROPERTY, section.FLAG_COMMAND, section.FLAG_COMMAND_ARGUMENT} {
+		hello.Set(flag, true)
+	}
+	send(hello)
+	values := telematics.Request{Sequence: telematics.Sequence(), Timestamp: 1500000000, Values: []section.ModulePropertyValue{{ModuleId: 3, Values: map[byte]interface{}{2: float32(12.5)}}}}
+	values.Set(section.FLAG_MODULE_PROPERTY_VALUE, true)
+	send(values)
+
+	ts := httptest.NewServer(New(srv))
+	defer ts.Close()
+	get := func(path string, v interface{}) int {
+		resp, err := http.Get(ts.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		json.NewDecoder(resp.Body).Decode(v)
+		return resp.StatusCode
+	}
+
+	var devices []DeviceBody
+	if get("/devices", &devices); len(devices) != 1 || devices[0].Device != "dev 1" {
+		t.Errorf("devices wrong: %v", devices)
+	}
+	var d DeviceBody
+	if status := get("/devices/dev%201", &d); status != http.StatusOK || !d.Connected || d.Identification.CodeText != "dev 1" {
+		t.Errorf("device wrong: %v %v", status, d)
+	}
+	if status := get("/devices/dev2", &d); status != http.StatusNotFound {
+		t.Errorf("unknown device status wrong: %v", status)
+	}
+	var c telematics.Configuration
+	if get("/devices/dev%201/configuration", &c); len(c.Commands) != 1 || c.Commands[0].Name != "reboot" {
+		t.Errorf("configuration wrong: %v", c)
+	}
+	var vs []ValueBody
+	if get("/devices/dev%201/values", &vs); len(vs) != 1 || vs[0].Property != "speed" || string(vs[0].Value) != "12.5" || vs[0].Time.Unix() != 1500000000 {
+		t.Errorf("values wrong: %v", vs)
+	}
+
+	delivered := make(chan telematics.Packet, 1)
+	go func() {
+		p := telematics.Packet{}
+		reader.Read(&p)
+		delivered <- p
+	}()
+	resp, err := http.Post(ts.URL+"/devices/dev%201/commands", "application/json", strings.NewReader(`{"module":"tracker","command":"reboot","arguments":{"delay":30}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result CommandResult
+	json.NewDecoder(resp.Body).Decode(&result)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted || result.Id == 0 {
+		t.Errorf("command not queued: %v %v", resp.Status, result)
+	}
+	if p := <-delivered; len(p.Request.Executes) != 1 || p.Request.Executes[0].Arguments[1] != uint16(30) {
+		t.Errorf("command not delivered: %v", p.Request.Executes)
+	}
+
+	resp, _ = http.Post(ts.URL+"/devices/dev%201/commands", "application/json", strings.NewReader(`{"module":"tracker","command":"reboot","arguments":{"delay":-1}}`))
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("invalid command status wrong: %v", resp.Status)
+	}
+
+	device.Close()
+	<-done
+	d = DeviceBody{}
+	if status := get("/devices/dev%201", &d); status != http.StatusOK || d.Connected || d.Identification.CodeText != "dev 1" {
+		t.Errorf("disconnected device wrong: %v %v", status, d)
+	}
+	c = telematics.Configuration{}
+	if get("/devices/dev%201/configuration", &c); len(c.Commands) != 1 || c.Commands[0].Name != "reboot" {
+		t.Errorf("configuration of disconnected device wrong: %v", c)
+	}
+	vs = nil
+	if get("/devices/dev%201/values", &vs); len(vs) != 1 || vs[0].Module != "tracker" || vs[0].Property != "speed" {
+		t.Errorf("values of disconnected device wrong: %v", vs)
+	}
+	command := `{"module":"tracker","command":"reboot"}`
+	resp, _ = http.Post(ts.URL+"/devices/dev%201/commands", "application/json", strings.NewReader(command))
+	json.NewDecoder(resp.Body).Decode(&result)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted || result.Id == 0 {
+		t.Errorf("command of disconnected device not queued: %v %v", resp.Status, result)
+	}
+	if state, _ := srv.Tracker.State(result.Id); state != telematics.COMMAND_QUEUED {
+		t.Errorf("state wrong: %v", state)
+	}
+	resp, _ = http.Post(ts.URL+"/devices/dev2/commands", "application/json", strings.NewReader(command))
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("command of unknown device status wrong: %v", resp.Status)
+	}
+}
+
+func Test_GatewayWithoutState(t *testing.T) {
+	srv := &telematics.Server{}
+	ts := httptest.NewServer(New(srv))
+	defer ts.Close()
+	if srv.State != nil {
+		t.Error("server state set by gateway")
+	}
+	resp, err := http.Get(ts.URL + "/devices/dev1/values")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("unknown device status wrong: %v", resp.Status)
+	}
+}
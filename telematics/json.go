@@ -0,0 +1,172 @@
+package telematics
+
+import (
+	"encoding/json"
+
+	"github.com/boiledgas/protocol/telematics/section"
+	"github.com/boiledgas/protocol/utils"
+)
+
+type configurationJSON struct {
+	Hash       byte                      `json:"hash"`
+	Modules    []section.Module          `json:"modules"`
+	Properties []section.ModuleProperty  `json:"properties"`
+	Commands   []section.Command         `json:"commands"`
+	Arguments  []section.CommandArgument `json:"arguments"`
+}
+
+func (c Configuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(configurationJSON(c))
+}
+
+func (c *Configuration) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*configurationJSON)(c))
+}
+
+var requestFlags = []utils.FlagName{
+	{Flag: section.FLAG_IDENTIFICATION, Name: "identification"},
+	{Flag: section.FLAG_AUTHENTICATION, Name: "authentication"},
+	{Flag: section.FLAG_SUPPORTED, Name: "supported"},
+	{Flag: section.FLAG_MODULE, Name: "module"},
+	{Flag: section.FLAG_MODULE_PROPERTY, Name: "moduleProperty"},
+	{Flag: section.FLAG_MODULE_PROPERTY_VALUE, Name: "modulePropertyValue"},
+	{Flag: section.FLAG_MODULE_PROPERTY_DISABLED, Name: "modulePropertyDisabled"},
+	{Flag: section.FLAG_COMMAND, Name: "command"},
+	{Flag: section.FLAG_COMMAND_ARGUMENT, Name: "commandArgument"},
+	{Flag: section.FLAG_COMMAND_EXECUTE, Name: "commandExecute"},
+}
+
+type requestJSON struct {
+	Flags     map[string]bool                 `json:"flags"`
+	Sequence  byte                            `json:"sequence"`
+	Timestamp int32                           `json:"timestamp"`
+	Id        *section.Identification         `json:"identification,omitempty"`
+	Auth      *section.Authentication         `json:"authentication,omitempty"`
+	Sup       *section.Supported              `json:"supported,omitempty"`
+	Conf      *Configuration                  `json:"configuration,omitempty"`
+	Values    []section.ModulePropertyValue   `json:"values,omitempty"`
+	Executes  []section.CommandExecute        `json:"executes,omitempty"`
+	Disabled  []section.ModulePropertyDisable `json:"disabled,omitempty"`
+}
+
+// MarshalJSON of request, sections not flagged are omitted
+func (r Request) MarshalJSON() ([]byte, error) {
+	j := requestJSON{
+		Flags:     r.Named(requestFlags),
+		Sequence:  r.Sequence,
+		Timestamp: r.Timestamp,
+		Values:    r.Values,
+		Executes:  r.Executes,
+		Disabled:  r.Disabled,
+	}
+	if r.Has(section.FLAG_IDENTIFICATION) {
+		j.Id = &r.Id
+	}
+	if r.Has(section.FLAG_AUTHENTICATION) {
+		j.Auth = &r.Auth
+	}
+	if r.Has(section.FLAG_SUPPORTED) {
+		j.Sup = &r.Sup
+	}
+	if r.HasConfiguration() {
+		j.Conf = &r.Conf
+	}
+	return json.Marshal(j)
+}
+
+func (r *Request) UnmarshalJSON(data []byte) error {
+	var j requestJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	*r = Request{
+		Sequence:  j.Sequence,
+		Timestamp: j.Timestamp,
+		Values:    j.Values,
+		Executes:  j.Executes,
+		Disabled:  j.Disabled,
+	}
+	if j.Id != nil {
+		r.Id = *j.Id
+	}
+	if j.Auth != nil {
+		r.Auth = *j.Auth
+	}
+	if j.Sup != nil {
+		r.Sup = *j.Sup
+	}
+	if j.Conf != nil {
+		r.Conf = *j.Conf
+	}
+	return r.SetNamed(requestFlags, j.Flags)
+}
+
+var responseFlags = []utils.FlagName{
+	{Flag: uint16(RESPONSE_AUTHORIZATION), Name: "authorization"},
+	{Flag: uint16(RESPONSE_DESCRIPTION), Name: "description"},
+	{Flag: uint16(RESPONSE_EXECUTED), Name: "executed"},
+	{Flag: uint16(RESPONSE_CHALLENGE), Name: "challenge"},
+	{Flag: uint16(RESPONSE_ERROR), Name: "error"},
+}
+
+type responseJSON struct {
+	Flags    map[string]bool `json:"flags"`
+	Sequence byte            `json:"sequence"`
+	Nonce    []byte          `json:"nonce,omitempty"`
+	Crc      byte            `json:"crc"`
+}
+
+func (r Response) MarshalJSON() ([]byte, error) {
+	flags := utils.Flags8(r.Flags)
+	return json.Marshal(responseJSON{Flags: flags.Named(responseFlags), Sequence: r.Sequence, Nonce: r.Nonce, Crc: r.Crc})
+}
+
+func (r *Response) UnmarshalJSON(data []byte) error {
+	var j responseJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	var flags utils.Flags8
+	if err := flags.SetNamed(responseFlags, j.Flags); err != nil {
+		return err
+	}
+	*r = Response{Flags: ResponseFlag(flags), Sequence: j.Sequence, Nonce: j.Nonce, Crc: j.Crc}
+	return nil
+}
+
+var packetFlags = []utils.FlagName{
+	{Flag: uint16(FLAG_REQUEST), Name: "request"},
+	{Flag: uint16(FLAG_RESPONSE), Name: "response"},
+}
+
+type packetJSON struct {
+	Flags    map[string]bool `json:"flags"`
+	Request  *Request        `json:"request,omitempty"`
+	Response *Response       `json:"response,omitempty"`
+}
+
+func (p Packet) MarshalJSON() ([]byte, error) {
+	j := packetJSON{Flags: p.Named(packetFlags)}
+	if p.Has(FLAG_REQUEST) {
+		j.Request = &p.Request
+	}
+	if p.Has(FLAG_RESPONSE) {
+		j.Response = &p.Response
+	}
+	return json.Marshal(j)
+}
+
+func (p *Packet) UnmarshalJSON(data []byte) error {
+	var j packetJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	*p = Packet{}
+	if j.Request != nil {
+		p.Request = *j.Request
+	}
+	if j.Response != nil {
+		p.Response = *j.Response
+	}
+	return p.SetNamed(packetFlags, j.Flags)
+}
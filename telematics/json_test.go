@@ -0,0 +1,126 @@
+package telematics
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boiledgas/protocol/telematics/section"
+	"github.com/boiledgas/protocol/telematics/value"
+)
+
+func Test_ConfigurationJSON(t *testing.T) {
+	conf := testConfiguration()
+	data, err := json.Marshal(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := Configuration{}
+	if err := json.Unmarshal(data, &res); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(conf, res) {
+		t.Errorf("configuration wrong: %v != %v (%s)", conf, res, data)
+	}
+}
+
+func Test_RequestJSON(t *testing.T) {
+	conf := testConfiguration()
+	gps := value.Gps{Latitude: 55.75, Longitude: 37.61, Sat: 9}
+	gps.Set(value.GPS_FLAG_LATLNG, true)
+	gps.Set(value.GPS_FLAG_SATELLITES, true)
+	req := Request{Sequence: 7, Timestamp: 1500000000}
+	req.Id = section.Identification{CodeText: "device1", Type: section.DEVICETYPE_CAR}
+	req.Id.Set(section.IDENTIFICATION_FLAGS_CODETEXT, true)
+	req.Id.Set(section.IDENTIFICATION_FLAGS_DEVICETYPE, true)
+	req.Set(section.FLAG_IDENTIFICATION, true)
+	req.Values = []section.ModulePropertyValue{{
+		ModuleId: 1,
+		Values:   map[byte]interface{}{1: gps, 2: float32(21.5), 4: 90 * time.Second},
+		Types:    map[byte]value.DataType{1: value.GPS, 2: value.Temperature, 4: value.Timespan},
+	}}
+	req.Set(section.FLAG_MODULE_PROPERTY_VALUE, true)
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{`"type":"Temperature"`, `"latlng":true`, `"deviceType":"Car"`, `"identification":true`} {
+		if !strings.Contains(string(data), s) {
+			t.Errorf("%v not found in %s", s, data)
+		}
+	}
+
+	res := Request{}
+	if err := json.Unmarshal(data, &res); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(req, res) {
+		t.Errorf("request wrong: %v != %v (%s)", req, res, data)
+	}
+
+	buf := bytes.Buffer{}
+	w := NewWriter(&buf)
+	w.Configuration = &conf
+	w.WriteRequest(&res)
+	r := NewReader(&buf)
+	r.Configuration = &conf
+	wire := Request{}
+	if err := r.ReadRequest(&wire); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(req, wire) {
+		t.Errorf("wire request wrong: %v != %v", req, wire)
+	}
+}
+
+func Test_PacketJSON(t *testing.T) {
+	p := Packet{Response: Response{Flags: RESPONSE_ERROR | RESPONSE_DESCRIPTION, Sequence: 7}}
+	p.Set(FLAG_RESPONSE, true)
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := Packet{}
+	if err := json.Unmarshal(data, &res); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(p, res) {
+		t.Errorf("packet wrong: %v != %v (%s)", p, res, data)
+	}
+}
+
+func Test_UnknownEnumJSON(t *testing.T) {
+	id := section.Identification{Type: section.DeviceType(0x42)}
+	cell := value.Cell{CID: 7}
+	fix, st := value.GpsFix(0x42), section.Type(0x42)
+	data, err := json.Marshal(struct {
+		Id      section.Identification
+		Cell    value.Cell
+		Fix     value.GpsFix
+		Section section.Type
+	}{id, cell, fix, st})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var res struct {
+		Id      section.Identification
+		Cell    value.Cell
+		Fix     value.GpsFix
+		Section section.Type
+	}
+	if err := json.Unmarshal(data, &res); err != nil {
+		t.Fatalf("%v (%s)", err, data)
+	}
+	if res.Id.Type != id.Type || res.Cell != cell || res.Fix != fix || res.Section != st {
+		t.Errorf("unknown values wrong: %v %v %v %v (%s)", res.Id.Type, res.Cell, res.Fix, res.Section, data)
+	}
+	for _, name := range []string{`""`, `"Bogus"`} {
+		if err := json.Unmarshal([]byte(name), &st); err == nil {
+			t.Errorf("section type %v accepted", name)
+		}
+	}
+}
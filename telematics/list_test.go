@@ -0,0 +1,94 @@
+package telematics
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/boiledgas/protocol/telematics/section"
+	"github.com/boiledgas/protocol/telematics/value"
+)
+
+func Test_PropertyLabel(t *testing.T) {
+	conf := testConfiguration()
+	if label, ok := conf.Label(1, 3, byte(3)); !ok || label != "Driving" {
+		t.Errorf("label wrong: %v %v", label, ok)
+	}
+	if _, ok := conf.Label(1, 3, byte(2)); ok {
+		t.Error("value not in list has label")
+	}
+	var p section.ModuleProperty
+	conf.GetProperty(1, 3, &p)
+	if v, err := p.Parse("driving"); err != nil || v != byte(3) {
+		t.Errorf("parse wrong: %v %v", v, err)
+	}
+	if _, err := p.Parse("Flying"); !errors.Is(err, section.ErrNotInList) {
+		t.Errorf("parse error wrong: %v", err)
+	}
+
+	f := Flattener{Configuration: &conf}
+	req := Request{Timestamp: 1}
+	req.Values = []section.ModulePropertyValue{{ModuleId: 1, Values: map[byte]interface{}{3: byte(1)}}}
+	if points := f.Flatten("d", &req); points[0].Fields["label"] != "Parked" {
+		t.Errorf("point label wrong: %v", points[0])
+	}
+}
+
+func Test_PropertyLabelRead(t *testing.T) {
+	conf := testConfiguration()
+	req := Request{Sequence: 1, Conf: conf}
+	req.Set(section.FLAG_MODULE, true)
+	req.Set(section.FLAG_MODULE_PROPERTY, true)
+	buf := bytes.Buffer{}
+	if err := NewWriter(&buf).WriteRequest(&req); err != nil {
+		t.Fatal(err)
+	}
+	res := Request{}
+	if err := NewReader(&buf).ReadRequest(&res); err != nil {
+		t.Fatal(err)
+	}
+	if label, ok := res.Conf.Label(1, 3, byte(3)); !ok || label != "Driving" {
+		t.Errorf("label of read configuration wrong: %v %v", label, ok)
+	}
+	var p section.ModuleProperty
+	res.Conf.GetProperty(1, 3, &p)
+	if len(p.List) != 2 {
+		t.Errorf("list of read configuration wrong: %v", p.List)
+	}
+	if v, err := p.Parse("Parked"); err != nil || v != byte(1) {
+		t.Errorf("lookup of read configuration wrong: %v %v", v, err)
+	}
+}
+
+func Test_BitmaskLabels(t *testing.T) {
+	p := section.ModuleProperty{ModuleId: 1, Id: 5, Type: value.UShort, Name: "alarms"}
+	p.List = []value.NameValue{{Name: "Door", Value: uint16(0x01)}, {Name: "Hood", Value: uint16(0x02)}, {Name: "Tow", Value: uint16(0x08)}}
+	p.Set(section.MODULE_PROPERTY_FLAGS_LIST, true)
+	p.Set(section.MODULE_PROPERTY_FLAGS_BITMASK, true)
+
+	if label, ok := p.Label(uint16(0x09)); !ok || label != "Door|Tow" {
+		t.Errorf("bitmask label wrong: %v %v", label, ok)
+	}
+	if label, ok := p.Label(uint16(0)); !ok || label != "" {
+		t.Errorf("empty bitmask label wrong: %v %v", label, ok)
+	}
+	if _, ok := p.Label(uint16(0x04)); ok {
+		t.Error("unknown bit has label")
+	}
+	if v, err := p.Parse("Hood | Door"); err != nil || v != uint16(0x03) {
+		t.Errorf("bitmask parse wrong: %v %v", v, err)
+	}
+	labels := []string{" Tow "}
+	if v, err := p.Check(labels); err != nil || v != uint16(0x08) {
+		t.Errorf("bitmask check wrong: %v %v", v, err)
+	}
+	if labels[0] != " Tow " {
+		t.Errorf("labels of caller changed: %q", labels)
+	}
+	if _, err := p.Check(uint16(0x10)); !errors.Is(err, section.ErrNotInList) {
+		t.Errorf("bitmask list error wrong: %v", err)
+	}
+	if v, err := p.Check(uint16(0x0b)); err != nil || v != uint16(0x0b) {
+		t.Errorf("bitmask value wrong: %v %v", v, err)
+	}
+}
@@ -0,0 +1,196 @@
+package telematics
+
+import (
+	"errors"
+	"io"
+	"net"
+
+	"github.com/boiledgas/protocol/telematics/section"
+	"github.com/boiledgas/protocol/telematics/value"
+)
+
+// metrics of readers, writers and server, names follow prometheus conventions:
+// counters end with _total, durations are in seconds
+const (
+	METRIC_PACKETS          = "telematics_packets_total"            // direction, type
+	METRIC_SECTIONS         = "telematics_sections_total"           // direction, section
+	METRIC_VALUES           = "telematics_values_total"             // direction, type
+	METRIC_BYTES            = "telematics_bytes_total"              // direction
+	METRIC_CRC_FAILURES     = "telematics_crc_failures_total"       //
+	METRIC_DECODE_ERRORS    = "telematics_decode_errors_total"      // kind
+	METRIC_SESSIONS         = "telematics_sessions_active"          //
+	METRIC_REQUEST_DURATION = "telematics_request_duration_seconds" //
+)
+
+const (
+	DIRECTION_IN  = "in"
+	DIRECTION_OUT = "out"
+)
+
+// Metrics receives measurements, implementation must be safe for concurrent use
+type Metrics interface {
+	// Add adds delta to counter or gauge
+	Add(name string, delta float64, labels ...Label)
+	// Observe records sample of distribution
+	Observe(name string, v float64, labels ...Label)
+}
+
+type Label struct {
+	Name  string
+	Value string
+}
+
+// byteCounter counts bytes passed to reader or writer
+type byteCounter struct {
+	reader io.Reader
+	writer io.Writer
+	n      int64
+}
+
+func (c *byteCounter) Read(p []byte) (n int, err error) {
+	n, err = c.reader.Read(p)
+	c.n += int64(n)
+	return
+}
+
+func (c *byteCounter) Write(p []byte) (n int, err error) {
+	n, err = c.writer.Write(p)
+	c.n += int64(n)
+	return
+}
+
+func (c *byteCounter) count() int64 {
+	if c == nil {
+		return 0
+	}
+	return c.n
+}
+
+func (w *TelematicsWriter) written() int64 {
+	return w.counter.count()
+}
+
+// measure counts packet read with n bytes, err and panic of reader are counted as decode errors
+func (r *TelematicsReader) measure(p *Packet, n int64, err error, panicked bool) {
+	switch {
+	case panicked:
+		measureBytes(r.Metrics, DIRECTION_IN, n)
+		r.Metrics.Add(METRIC_DECODE_ERRORS, 1, Label{"kind", "malformed"})
+	case err == io.EOF && n == 0, err != nil && isTimeout(err):
+		measureBytes(r.Metrics, DIRECTION_IN, n)
+	case err != nil:
+		measureBytes(r.Metrics, DIRECTION_IN, n)
+		kind := errorKind(err)
+		if kind == "crc" {
+			r.Metrics.Add(METRIC_CRC_FAILURES, 1)
+		}
+		r.Metrics.Add(METRIC_DECODE_ERRORS, 1, Label{"kind", kind})
+	case p.Has(FLAG_REQUEST):
+		measureRequest(r.Metrics, DIRECTION_IN, &p.Request, n)
+	case p.Has(FLAG_RESPONSE):
+		measureResponse(r.Metrics, DIRECTION_IN, n)
+	default:
+		measureBytes(r.Metrics, DIRECTION_IN, n)
+		r.Metrics.Add(METRIC_PACKETS, 1, Label{"direction", DIRECTION_IN}, Label{"type", "unknown"})
+	}
+}
+
+// errorKind classifies error of reader
+func errorKind(err error) string {
+	var validation *ValidationError
+	var netErr net.Error
+	switch {
+	case errors.Is(err, ErrCrcNotValid):
+		return "crc"
+	case errors.Is(err, ErrSectionUnknown):
+		return "section"
+	case errors.Is(err, ErrPropertyUnknown):
+		return "property"
+	case errors.As(err, &validation):
+		return "validation"
+	case err == io.EOF || err == io.ErrUnexpectedEOF:
+		return "truncated"
+	case errors.As(err, &netErr):
+		return "io"
+	}
+	return "other"
+}
+
+func measureBytes(m Metrics, direction string, n int64) {
+	if n > 0 {
+		m.Add(METRIC_BYTES, float64(n), Label{"direction", direction})
+	}
+}
+
+func measureResponse(m Metrics, direction string, n int64) {
+	measureBytes(m, direction, n)
+	m.Add(METRIC_PACKETS, 1, Label{"direction", direction}, Label{"type", "response"})
+}
+
+// measureRequest counts request, its sections and values
+func measureRequest(m Metrics, direction string, r *Request, n int64) {
+	measureBytes(m, direction, n)
+	dir := Label{"direction", direction}
+	m.Add(METRIC_PACKETS, 1, dir, Label{"type", "request"})
+	var flags [16]uint16
+	r.Load(&flags)
+	for _, flag := range flags {
+		if flag == 0 {
+			continue
+		}
+		t := section.ToSectionType(flag)
+		count := 1
+		switch t {
+		case section.SECTION_MODULE:
+			count = len(r.Conf.Modules)
+		case section.SECTION_MODULE_PROPERTY:
+			count = len(r.Conf.Properties)
+		case section.SECTION_COMMAND:
+			count = len(r.Conf.Commands)
+		case section.SECTION_COMMAND_ARGUMENT:
+			count = len(r.Conf.Arguments)
+		case section.SECTION_MODULE_PROPERTY_VALUE:
+			count = len(r.Values)
+		case section.SECTION_MODULE_PROPERTY_DISABLED:
+			count = len(r.Disabled)
+		case section.SECTION_COMMAND_EXECUTE:
+			count = len(r.Executes)
+		}
+		if count > 0 {
+			m.Add(METRIC_SECTIONS, float64(count), dir, Label{"section", t.String()})
+		}
+	}
+	types := make(map[value.DataType]int)
+	for _, s := range r.Values {
+		for id, v := range s.Values {
+			t, ok := s.Types[id]
+			if !ok {
+				t = value.TypeOf(v)
+			}
+			types[t]++
+		}
+	}
+	for t, count := range types {
+		m.Add(METRIC_VALUES, float64(count), dir, Label{"type", t.String()})
+	}
+}
+
+// pendingMetrics keeps measurements of packets written to buffer until buffer is sent,
+// it is not safe for concurrent use
+type pendingMetrics []func(m Metrics)
+
+func (p *pendingMetrics) Add(name string, delta float64, labels ...Label) {
+	*p = append(*p, func(m Metrics) { m.Add(name, delta, labels...) })
+}
+
+func (p *pendingMetrics) Observe(name string, v float64, labels ...Label) {
+	*p = append(*p, func(m Metrics) { m.Observe(name, v, labels...) })
+}
+
+// flush passes kept measurements to m
+func (p *pendingMetrics) flush(m Metrics) {
+	for _, f := range *p {
+		f(m)
+	}
+	*p = nil
+}
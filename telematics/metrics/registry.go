@@ -0,0 +1,175 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/boiledgas/protocol/telematics"
+)
+
+// help of metrics measured by telematics package
+var help = map[string]string{
+	telematics.METRIC_PACKETS:          "Packets read and written by type.",
+	telematics.METRIC_SECTIONS:         "Sections of requests read and written by section type.",
+	telematics.METRIC_VALUES:           "Property values read and written by data type.",
+	telematics.METRIC_BYTES:            "Bytes read and written.",
+	telematics.METRIC_CRC_FAILURES:     "Packets read with invalid checksum.",
+	telematics.METRIC_DECODE_ERRORS:    "Packets not decoded by kind of error.",
+	telematics.METRIC_SESSIONS:         "Sessions served.",
+	telematics.METRIC_REQUEST_DURATION: "Time of request processing by server.",
+}
+
+// Registry keeps measurements in memory and exports them in prometheus text format.
+// Metric added with _total suffix is counter, other added metric is gauge, observed metric is summary
+type Registry struct {
+	mutex    sync.Mutex
+	families map[string]*family
+}
+
+type kind string
+
+const (
+	counter kind = "counter"
+	gauge   kind = "gauge"
+	summary kind = "summary"
+)
+
+type family struct {
+	kind   kind
+	series map[string]*series // by formatted labels
+}
+
+type series struct {
+	labels string
+	value  float64 // sum of summary
+	count  uint64  // samples of summary
+}
+
+func NewRegistry() *Registry {
+	return &Registry{families: make(map[string]*family)}
+}
+
+func (r *Registry) Add(name string, delta float64, labels ...telematics.Label) {
+	k := gauge
+	if strings.HasSuffix(name, "_total") {
+		k = counter
+	}
+	r.mutex.Lock()
+	s := r.series(name, k, labels)
+	s.value += delta
+	r.mutex.Unlock()
+}
+
+func (r *Registry) Observe(name string, v float64, labels ...telematics.Label) {
+	r.mutex.Lock()
+	s := r.series(name, summary, labels)
+	s.value += v
+	s.count++
+	r.mutex.Unlock()
+}
+
+// Value returns value of counter or gauge, sum of summary
+func (r *Registry) Value(name string, labels ...telematics.Label) float64 {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if f, ok := r.families[name]; ok {
+		if s, ok := f.series[formatLabels(labels)]; ok {
+			return s.value
+		}
+	}
+	return 0
+}
+
+// series returns series of labels, mutex must be held
+func (r *Registry) series(name string, k kind, labels []telematics.Label) *series {
+	if r.families == nil {
+		r.families = make(map[string]*family)
+	}
+	f, ok := r.families[name]
+	if !ok {
+		f = &family{kind: k, series: make(map[string]*series)}
+		r.families[name] = f
+	}
+	key := formatLabels(labels)
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{labels: key}
+		f.series[key] = s
+	}
+	return s
+}
+
+// WritePrometheus writes metrics in prometheus text exposition format ordered by name and labels
+func (r *Registry) WritePrometheus(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	r.mutex.Lock()
+	names := make([]string, 0, len(r.families))
+	for name := range r.families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		f := r.families[name]
+		if h, ok := help[name]; ok {
+			fmt.Fprintf(bw, "# HELP %s %s\n", name, h)
+		}
+		fmt.Fprintf(bw, "# TYPE %s %s\n", name, f.kind)
+		keys := make([]string, 0, len(f.series))
+		for key := range f.series {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			s := f.series[key]
+			if f.kind == summary {
+				fmt.Fprintf(bw, "%s_sum%s %s\n", name, s.labels, formatValue(s.value))
+				fmt.Fprintf(bw, "%s_count%s %d\n", name, s.labels, s.count)
+			} else {
+				fmt.Fprintf(bw, "%s%s %s\n", name, s.labels, formatValue(s.value))
+			}
+		}
+	}
+	r.mutex.Unlock()
+	return bw.Flush()
+}
+
+// ServeHTTP exports metrics for prometheus scraping
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	r.WritePrometheus(w)
+}
+
+// formatLabels returns labels sorted by name in exposition format, empty if there are no labels
+func formatLabels(labels []telematics.Label) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	sorted := append([]telematics.Label(nil), labels...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	parts := make([]string, len(sorted))
+	for i, l := range sorted {
+		parts[i] = l.Name + `="` + escaper.Replace(l.Value) + `"`
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+var escaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
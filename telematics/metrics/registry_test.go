@@ -0,0 +1,176 @@
+package metrics
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/boiledgas/protocol/telematics"
+	"github.com/boiledgas/protocol/telematics/section"
+	"github.com/boiledgas/protocol/telematics/value"
+)
+
+func Test_Registry(t *testing.T) {
+	conf := telematics.Configuration{
+		Modules:    []section.Module{{Id: 3}},
+		Properties: []section.ModuleProperty{{ModuleId: 3, Id: 1, Type: value.Bool}, {ModuleId: 3, Id: 2, Type: value.Speed}},
+	}
+	registry := NewRegistry()
+	srv := telematics.Server{Metrics: registry}
+	device, conn := net.Pipe()
+	done := make(chan error)
+	go func() { done <- srv.ServeConn(conn) }()
+
+	w := telematics.NewWriter(device)
+	w.Configuration = &conf
+	reader := telematics.NewReader(device)
+	reader.Configuration = &conf
+	send := func(r telematics.Request) {
+		if err := w.WriteRequest(&r); err != nil {
+			t.Fatal(err)
+		}
+		p := telematics.Packet{}
+		if err := reader.Read(&p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	hello := telematics.Request{Sequence: telematics.Sequence(), Id: section.Identification{Code: 42}, Conf: conf}
+	hello.Id.Set(section.IDENTIFICATION_FLAGS_CODE, true)
+	for _, flag := range []uint16{section.FLAG_IDENTIFICATION, section.FLAG_MODULE, section.FLAG_MODULE_PROPERTY} {
+		hello.Set(flag, true)
+	}
+	send(hello)
+	values := telematics.Request{Sequence: telematics.Sequence(), Values: []section.ModulePropertyValue{{ModuleId: 3, Values: map[byte]interface{}{1: true, 2: float32(12.5)}}}}
+	values.Set(section.FLAG_MODULE_PROPERTY_VALUE, true)
+	send(values)
+
+	label := func(name, value string) telematics.Label { return telematics.Label{Name: name, Value: value} }
+	in, out := label("direction", "in"), label("direction", "out")
+	if v := registry.Value(telematics.METRIC_SESSIONS); v != 1 {
+		t.Errorf("active sessions wrong: %v", v)
+	}
+	if v := registry.Value(telematics.METRIC_PACKETS, in, label("type", "request")); v != 2 {
+		t.Errorf("requests read wrong: %v", v)
+	}
+	if v := registry.Value(telematics.METRIC_SECTIONS, in, label("section", "ModuleProperty")); v != 2 {
+		t.Errorf("property sections wrong: %v", v)
+	}
+	if v := registry.Value(telematics.METRIC_VALUES, in, label("type", value.Speed.String())); v != 1 {
+		t.Errorf("speed values wrong: %v", v)
+	}
+
+	device.Write([]byte{telematics.PACKET_TYPE_REQUEST, 2, 1, 0, 0, 0, 0, 0x77})
+	device.Close()
+	if err := <-done; err == nil {
+		t.Error("unknown section read")
+	}
+	// responses are counted after write to connection returns
+	if v := registry.Value(telematics.METRIC_PACKETS, out, label("type", "response")); v != 2 {
+		t.Errorf("responses written wrong: %v", v)
+	}
+	if v := registry.Value(telematics.METRIC_BYTES, out); v != 8 {
+		t.Errorf("bytes written wrong: %v", v)
+	}
+	if v := registry.Value(telematics.METRIC_DECODE_ERRORS, label("kind", "section")); v != 1 {
+		t.Errorf("decode errors wrong: %v", v)
+	}
+	if v := registry.Value(telematics.METRIC_SESSIONS); v != 0 {
+		t.Errorf("session not closed: %v", v)
+	}
+
+	var buf bytes.Buffer
+	if err := registry.WritePrometheus(&buf); err != nil {
+		t.Fatal(err)
+	}
+	text := buf.String()
+	for _, line := range []string{
+		"# TYPE telematics_packets_total counter\n",
+		`telematics_packets_total{direction="in",type="request"} 2` + "\n",
+		"# TYPE telematics_sessions_active gauge\ntelematics_sessions_active 0\n",
+		"# TYPE telematics_request_duration_seconds summary\n",
+		"telematics_request_duration_seconds_count 2\n",
+	} {
+		if !strings.Contains(text, line) {
+			t.Errorf("exposition has no %q:\n%v", line, text)
+		}
+	}
+}
+
+// failedConn reads packets of reader and fails writes
+type failedConn struct {
+	*bytes.Reader
+}
+
+func (c failedConn) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func Test_RegistryFailedWrite(t *testing.T) {
+	var buf bytes.Buffer
+	r := telematics.Request{Sequence: telematics.Sequence()}
+	if err := telematics.NewWriter(&buf).WriteRequest(&r); err != nil {
+		t.Fatal(err)
+	}
+	registry := NewRegistry()
+	srv := telematics.Server{Metrics: registry}
+	if err := srv.ServeConn(failedConn{bytes.NewReader(buf.Bytes())}); err == nil {
+		t.Error("failed write not returned")
+	}
+	out := telematics.Label{Name: "direction", Value: "out"}
+	if v := registry.Value(telematics.METRIC_PACKETS, out, telematics.Label{Name: "type", Value: "response"}); v != 0 {
+		t.Errorf("response not sent counted: %v", v)
+	}
+	if v := registry.Value(telematics.METRIC_BYTES, out); v != 0 {
+		t.Errorf("bytes not sent counted: %v", v)
+	}
+}
+
+// rwBuffer reads packets of reader and discards writes
+type rwBuffer struct {
+	*bytes.Reader
+	written bytes.Buffer
+}
+
+func (c *rwBuffer) Write(p []byte) (int, error) {
+	return c.written.Write(p)
+}
+
+func Test_RegistryCrcFailure(t *testing.T) {
+	var buf bytes.Buffer
+	r := telematics.Request{Sequence: 1, Timestamp: 1500000000}
+	if err := telematics.NewWriter(&buf).WriteRequest(&r); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+	if data[len(data)-1] == 0 {
+		t.Fatalf("checksum not written: %x", data)
+	}
+	if err := telematics.NewReader(bytes.NewReader(data)).ReadRequest(&telematics.Request{}); err != nil {
+		t.Fatalf("valid packet not read: %v", err)
+	}
+
+	data[len(data)-1] ^= 0xff
+	if err := telematics.NewReader(bytes.NewReader(data)).ReadRequest(&telematics.Request{}); !errors.Is(err, telematics.ErrCrcNotValid) {
+		t.Errorf("corrupted packet error wrong: %v", err)
+	}
+	registry := NewRegistry()
+	srv := telematics.Server{Metrics: registry}
+	if err := srv.ServeConn(&rwBuffer{Reader: bytes.NewReader(data)}); !errors.Is(err, telematics.ErrCrcNotValid) {
+		t.Errorf("serve error wrong: %v", err)
+	}
+	if v := registry.Value(telematics.METRIC_CRC_FAILURES); v != 1 {
+		t.Errorf("crc failures wrong: %v", v)
+	}
+	if v := registry.Value(telematics.METRIC_DECODE_ERRORS, telematics.Label{Name: "kind", Value: "crc"}); v != 1 {
+		t.Errorf("decode errors wrong: %v", v)
+	}
+}
+
+func Test_FormatLabels(t *testing.T) {
+	labels := formatLabels([]telematics.Label{{Name: "b", Value: "x\"\n\\"}, {Name: "a", Value: "1"}})
+	if labels != `{a="1",b="x\"\n\\"}` {
+		t.Errorf("labels wrong: %v", labels)
+	}
+}
@@ -0,0 +1,79 @@
+package telematics
+
+import (
+	"log"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/boiledgas/protocol/telematics/section"
+)
+
+// Middleware wraps handler of requests, it runs after packet is decoded and session is authenticated and may
+// inspect session, change request, answer instead of next handler or change its response
+type Middleware func(next Handler) Handler
+
+// Chain wraps handler by middlewares, first middleware is outermost and sees request first
+func Chain(h Handler, middlewares ...Middleware) Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		h = middlewares[i](h)
+	}
+	return h
+}
+
+// Logging logs device, sections and response of each request with processing time,
+// standard logger is used if logger is nil
+func Logging(logger *log.Logger) Middleware {
+	printf := log.Printf
+	if logger != nil {
+		printf = logger.Printf
+	}
+	return func(next Handler) Handler {
+		return HandlerFunc(func(s *Session, r *Request) Response {
+			start := time.Now()
+			resp := next.Handle(s, r)
+			printf("telematics: %v: request %v [%v] response %#02x in %v", deviceName(s), r.Sequence, sections(r), byte(resp.Flags), time.Since(start))
+			return resp
+		})
+	}
+}
+
+// Recovery answers request with error if next handler panics, panic is logged with stack,
+// standard logger is used if logger is nil, server recovers panics of middlewares without it
+func Recovery(logger *log.Logger) Middleware {
+	printf := log.Printf
+	if logger != nil {
+		printf = logger.Printf
+	}
+	return func(next Handler) Handler {
+		return HandlerFunc(func(s *Session, r *Request) (resp Response) {
+			defer func() {
+				if rec := recover(); rec != nil {
+					printf("telematics: %v: panic in request %v: %v\n%s", deviceName(s), r.Sequence, rec, debug.Stack())
+					resp = Response{Sequence: r.Sequence, Flags: RESPONSE_ERROR}
+				}
+			}()
+			return next.Handle(s, r)
+		})
+	}
+}
+
+func deviceName(s *Session) string {
+	if device := s.State().Device; device != "" {
+		return device
+	}
+	return "unknown device"
+}
+
+// sections returns names of request sections
+func sections(r *Request) string {
+	var flags [16]uint16
+	r.Load(&flags)
+	names := make([]string, 0, len(flags))
+	for _, flag := range flags {
+		if flag != 0 {
+			names = append(names, section.ToSectionType(flag).String())
+		}
+	}
+	return strings.Join(names, " ")
+}
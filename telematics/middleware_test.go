@@ -0,0 +1,104 @@
+package telematics
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/boiledgas/protocol/telematics/section"
+)
+
+func Test_Middleware(t *testing.T) {
+	var order []string
+	trace := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return HandlerFunc(func(s *Session, r *Request) Response {
+				order = append(order, name+" "+s.Device)
+				return next.Handle(s, r)
+			})
+		}
+	}
+	var buf bytes.Buffer
+	srv := Server{
+		Handler:    HandlerFunc(func(s *Session, r *Request) Response { panic("handler failed") }),
+		Middleware: []Middleware{Logging(log.New(&buf, "", 0)), Recovery(log.New(ioutil.Discard, "", 0)), trace("first"), trace("second")},
+	}
+	s := NewSession(ioutil.Discard)
+	r := Request{Sequence: 7, Id: section.Identification{Code: 42}}
+	r.Id.Set(section.IDENTIFICATION_FLAGS_CODE, true)
+	r.Set(section.FLAG_IDENTIFICATION, true)
+
+	resp := srv.Process(s, &r)
+	if resp.Flags != RESPONSE_ERROR || resp.Sequence != 7 {
+		t.Errorf("panic not recovered: %v", resp)
+	}
+	if strings.Join(order, ",") != "first 42,second 42" {
+		t.Errorf("middleware order wrong: %v", order)
+	}
+	if line := buf.String(); !strings.Contains(line, "42: request 7 [Identification] response 0x80") {
+		t.Errorf("request not logged: %q", line)
+	}
+
+	limit := func(next Handler) Handler {
+		return HandlerFunc(func(s *Session, r *Request) Response {
+			return Response{Flags: RESPONSE_ERROR | RESPONSE_DESCRIPTION}
+		})
+	}
+	srv.Middleware = []Middleware{limit, trace("third")}
+	order = nil
+	if resp = srv.Process(s, &r); resp.Flags != RESPONSE_ERROR|RESPONSE_DESCRIPTION || resp.Sequence != 7 || len(order) != 0 {
+		t.Errorf("request not answered by middleware: %v %v", resp, order)
+	}
+}
+
+func Test_MiddlewareAuthenticated(t *testing.T) {
+	var seen []string
+	srv := Server{
+		Authenticator: &StaticAuthenticator{Secrets: map[string][]byte{"dev1": []byte("secret")}},
+		ErrorLog:      log.New(ioutil.Discard, "", 0),
+		Middleware: []Middleware{func(next Handler) Handler {
+			return HandlerFunc(func(s *Session, r *Request) Response {
+				resp := next.Handle(s, r)
+				seen = append(seen, fmt.Sprintf("%q %v %#02x", s.Device, s.Authenticated(), byte(resp.Flags)))
+				return resp
+			})
+		}},
+	}
+	s := NewSession(ioutil.Discard)
+	wrong := authRequest("dev1", []byte("wrong"))
+	srv.Process(s, &wrong)
+	auth := authRequest("dev1", []byte("secret"))
+	srv.Process(s, &auth)
+	if strings.Join(seen, ",") != `"" false 0x81,"dev1" true 0x00` {
+		t.Errorf("middleware saw session before authentication: %v", seen)
+	}
+}
+
+func Test_ServerRecoversPanic(t *testing.T) {
+	srv := Server{
+		ErrorLog: log.New(ioutil.Discard, "", 0),
+		Handler:  HandlerFunc(func(s *Session, r *Request) Response { panic("handler failed") }),
+	}
+	device, conn := net.Pipe()
+	defer device.Close()
+	go srv.ServeConn(conn)
+
+	w, reader := NewWriter(device), NewReader(device)
+	for i := 0; i < 2; i++ {
+		r := Request{Sequence: Sequence()}
+		if err := w.WriteRequest(&r); err != nil {
+			t.Fatal(err)
+		}
+		p := Packet{}
+		if err := reader.Read(&p); err != nil {
+			t.Fatal(err)
+		}
+		if p.Response.Flags != RESPONSE_ERROR || p.Response.Sequence != r.Sequence {
+			t.Errorf("panic not answered with error: %v", p.Response)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+// Package mqtt bridges devices of telematics server and MQTT topics. Package has no MQTT client,
+// bridge uses Broker interface which is implemented by in-process MemoryBroker only,
+// client of real broker must be adapted to Broker by application
+package mqtt
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/boiledgas/protocol/telematics"
+)
+
+const DEFAULT_PREFIX = "devices"
+
+// Bridge publishes property values of sessions on {prefix}/{device}/{module}/{property}
+// and sends commands published on {prefix}/{device}/command to devices of Server.
+// Commands are queued in tracker of server if it is set, so disconnected devices receive them later
+type Bridge struct {
+	Server   *telematics.Server
+	Broker   Broker
+	Prefix   string // DEFAULT_PREFIX if empty
+	ErrorLog *log.Logger
+}
+
+// ValueMessage is payload of property value topic
+type ValueMessage struct {
+	Time   time.Time              `json:"time"`
+	Type   string                 `json:"type"`
+	Fields map[string]interface{} `json:"fields"`
+}
+
+// CommandMessage is payload of command topic, command and arguments are resolved by name
+type CommandMessage struct {
+	Module    string                 `json:"module"`
+	Command   string                 `json:"command"`
+	Arguments map[string]interface{} `json:"arguments,omitempty"`
+}
+
+func NewBridge(srv *telematics.Server, broker Broker) *Bridge {
+	return &Bridge{Server: srv, Broker: broker}
+}
+
+func (b *Bridge) prefix() string {
+	if b.Prefix == "" {
+		return DEFAULT_PREFIX
+	}
+	return b.Prefix
+}
+
+func (b *Bridge) logf(format string, args ...interface{}) {
+	if b.ErrorLog != nil {
+		b.ErrorLog.Printf(format, args...)
+	} else {
+		log.Printf(format, args...)
+	}
+}
+
+// level escapes characters not allowed in topic level as in url, escaped level is unique for name
+func level(name string) string {
+	return levelEscaper.Replace(name)
+}
+
+var levelEscaper = strings.NewReplacer("%", "%25", "/", "%2F", "+", "%2B", "#", "%23")
+
+// Topic returns topic of property value
+func (b *Bridge) Topic(device string, module string, property string) string {
+	return fmt.Sprintf("%v/%v/%v/%v", b.prefix(), level(device), level(module), level(property))
+}
+
+// Handler publishes requests of sessions and passes them to next handler, next may be nil
+func (b *Bridge) Handler(next telematics.Handler) telematics.Handler {
+	return telematics.HandlerFunc(func(s *telematics.Session, r *telematics.Request) telematics.Response {
+		if err := b.Publish(s, r); err != nil {
+			b.logf("mqtt: %v: %v", s.State().Device, err)
+		}
+		if next == nil {
+			return telematics.Response{Flags: telematics.RESPONSE_OK}
+		}
+		return next.Handle(s, r)
+	})
+}
+
+// Publish publishes property values of request
+func (b *Bridge) Publish(s *telematics.Session, r *telematics.Request) error {
+	state := s.State()
+	if state.Device == "" {
+		return nil
+	}
+	f := telematics.Flattener{Configuration: &state.Configuration}
+	for _, p := range f.Flatten(state.Device, r) {
+		payload, err := json.Marshal(ValueMessage{Time: p.Time, Type: p.Type.String(), Fields: p.Fields})
+		if err != nil {
+			return err
+		}
+		if err = b.Broker.Publish(b.Topic(p.Device, p.Module, p.Property), payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Subscribe subscribes command topics of all devices
+func (b *Bridge) Subscribe() error {
+	return b.Broker.Subscribe(b.prefix()+"/+/command", b.command)
+}
+
+func (b *Bridge) command(topic string, payload []byte) {
+	device, err := url.PathUnescape(strings.TrimSuffix(strings.TrimPrefix(topic, b.prefix()+"/"), "/command"))
+	if err == nil {
+		err = b.Command(device, payload)
+	}
+	if err != nil {
+		b.logf("mqtt: %v: %v", topic, err)
+	}
+}
+
+// Command sends command message to device, command is queued in tracker of server if it is set
+// and delivered to session of device. Without tracker device must be connected
+func (b *Bridge) Command(device string, payload []byte) error {
+	var m CommandMessage
+	if err := json.Unmarshal(payload, &m); err != nil {
+		return err
+	}
+	conf, ok := b.Server.Configuration(device)
+	if !ok {
+		return fmt.Errorf("device %v not known", device)
+	}
+	c := telematics.NewCommand(&conf, m.Module, m.Command)
+	for name, v := range m.Arguments {
+		c.Arg(name, v)
+	}
+	r, err := c.Request()
+	if err != nil {
+		return err
+	}
+	s, connected := b.Server.Session(device)
+	if tracker := b.Server.Tracker; tracker != nil {
+		if _, err = tracker.Queue(device, r.Executes[0]); err != nil || !connected {
+			return err
+		}
+		return b.Server.Deliver(s)
+	}
+	if !connected {
+		return fmt.Errorf("device %v not connected", device)
+	}
+	return s.Send(&r)
+}
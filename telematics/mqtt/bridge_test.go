@@ -0,0 +1,112 @@
+package mqtt
+
+import (
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/boiledgas/protocol/telematics"
+	"github.com/boiledgas/protocol/telematics/section"
+	"github.com/boiledgas/protocol/telematics/value"
+)
+
+func Test_Match(t *testing.T) {
+	for _, c := range []struct {
+		filter string
+		topic  string
+		match  bool
+	}{
+		{"devices/+/command", "devices/dev1/command", true},
+		{"devices/+/command", "devices/dev1/tracker/speed", false},
+		{"devices/#", "devices/dev1/tracker/speed", true},
+		{"devices/dev1/+", "devices/dev1", false},
+		{"devices/dev1", "devices/dev1", true},
+	} {
+		if Match(c.filter, c.topic) != c.match {
+			t.Errorf("%v matching %v wrong", c.filter, c.topic)
+		}
+	}
+}
+
+func Test_Bridge(t *testing.T) {
+	m := section.Module{Id: 3, Name: "tracker"}
+	m.Set(section.MODULE_FLAGS_NAME, true)
+	p := section.ModuleProperty{ModuleId: 3, Id: 2, Type: value.Speed, Name: "speed"}
+	p.Set(section.MODULE_PROPERTY_FLAGS_NAME, true)
+	c := section.Command{ModuleId: 3, Id: 1, Name: "reboot"}
+	c.Set(section.COMMAND_FLAGS_NAME, true)
+	a := section.CommandArgument{ModuleId: 3, CommandId: 1, Id: 1, Type: value.UShort, Name: "delay"}
+	a.Set(section.COMMAND_ARGUMENT_FLAGS_NAME, true)
+	conf := telematics.Configuration{Modules: []section.Module{m}, Properties: []section.ModuleProperty{p}, Commands: []section.Command{c}, Arguments: []section.CommandArgument{a}}
+
+	broker := NewMemoryBroker()
+	messages := make(chan string, 4)
+	broker.Subscribe("devices/#", func(topic string, payload []byte) {
+		messages <- topic + " " + string(payload)
+	})
+	srv := &telematics.Server{State: telematics.NewDeviceState()}
+	bridge := NewBridge(srv, broker)
+	srv.Handler = bridge.Handler(nil)
+	if err := bridge.Subscribe(); err != nil {
+		t.Fatal(err)
+	}
+	device, conn := net.Pipe()
+	done := make(chan error)
+	go func() { done <- srv.ServeConn(conn) }()
+	w := telematics.NewWriter(device)
+	w.Configuration = &conf
+	reader := telematics.NewReader(device)
+	reader.Configuration = &conf
+	device.SetDeadline(time.Now().Add(time.Second))
+	read := func() telematics.Packet {
+		p := telematics.Packet{}
+		if err := reader.Read(&p); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+
+	hello := telematics.Request{Sequence: telematics.Sequence(), Id: section.Identification{CodeText: "dev/1"}, Conf: conf}
+	hello.Id.Set(section.IDENTIFICATION_FLAGS_CODETEXT, true)
+	for _, flag := range []uint16{section.FLAG_IDENTIFICATION, section.FLAG_MODULE, section.FLAG_MODULE_PROPERTY, section.FLAG_COMMAND, section.FLAG_COMMAND_ARGUMENT} {
+		hello.Set(flag, true)
+	}
+	values := telematics.Request{Sequence: telematics.Sequence(), Timestamp: 1500000000, Values: []section.ModulePropertyValue{{ModuleId: 3, Values: map[byte]interface{}{2: float32(12.5)}}}}
+	values.Set(section.FLAG_MODULE_PROPERTY_VALUE, true)
+	for _, r := range []telematics.Request{hello, values} {
+		if err := w.WriteRequest(&r); err != nil {
+			t.Fatal(err)
+		}
+		if resp := read(); resp.Response.Flags != telematics.RESPONSE_OK {
+			t.Errorf("response wrong: %v", resp.Response)
+		}
+	}
+	if m := <-messages; m != `devices/dev%2F1/tracker/speed {"time":"2017-07-14T02:40:00Z","type":"Speed","fields":{"value":12.5}}` {
+		t.Errorf("message wrong: %v", m)
+	}
+
+	payload, _ := json.Marshal(CommandMessage{Module: "tracker", Command: "reboot", Arguments: map[string]interface{}{"delay": 30}})
+	go broker.Publish("devices/dev%2F1/command", payload)
+	if p := read(); len(p.Request.Executes) != 1 || p.Request.Executes[0].CommandId != 1 || p.Request.Executes[0].Arguments[1] != uint16(30) {
+		t.Errorf("command wrong: %v", p.Request.Executes)
+	}
+	<-messages
+
+	if err := bridge.Command("dev_1", payload); err == nil {
+		t.Error("command of unknown device sent")
+	}
+	device.Close()
+	<-done
+	if err := bridge.Command("dev/1", payload); err == nil {
+		t.Error("command of disconnected device sent without tracker")
+	}
+	tracker := telematics.NewTracker(telematics.NewMemoryCommandStore(), time.Minute)
+	srv.Tracker = tracker
+	if err := bridge.Command("dev/1", payload); err != nil {
+		t.Fatalf("command of disconnected device not queued: %v", err)
+	}
+	if r, _, _ := tracker.Request("dev/1"); len(r.Executes) != 1 {
+		t.Errorf("command not queued: %v", r.Executes)
+	}
+}
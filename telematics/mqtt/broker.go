@@ -0,0 +1,66 @@
+package mqtt
+
+import (
+	"strings"
+	"sync"
+)
+
+// MessageHandler receives messages of subscription
+type MessageHandler func(topic string, payload []byte)
+
+// Broker is client of MQTT broker used by bridge
+type Broker interface {
+	Publish(topic string, payload []byte) error
+	Subscribe(filter string, handler MessageHandler) error
+}
+
+// MemoryBroker is in-process broker, messages are delivered synchronously to matching subscriptions
+type MemoryBroker struct {
+	mutex         sync.RWMutex
+	subscriptions []subscription
+}
+
+type subscription struct {
+	filter  string
+	handler MessageHandler
+}
+
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{}
+}
+
+func (b *MemoryBroker) Publish(topic string, payload []byte) error {
+	b.mutex.RLock()
+	var handlers []MessageHandler
+	for _, s := range b.subscriptions {
+		if Match(s.filter, topic) {
+			handlers = append(handlers, s.handler)
+		}
+	}
+	b.mutex.RUnlock()
+	for _, h := range handlers {
+		h(topic, payload)
+	}
+	return nil
+}
+
+func (b *MemoryBroker) Subscribe(filter string, handler MessageHandler) error {
+	b.mutex.Lock()
+	b.subscriptions = append(b.subscriptions, subscription{filter: filter, handler: handler})
+	b.mutex.Unlock()
+	return nil
+}
+
+// Match returns true if topic matches filter with + (one level) and # (rest of levels) wildcards
+func Match(filter string, topic string) bool {
+	f, t := strings.Split(filter, "/"), strings.Split(topic, "/")
+	for i, level := range f {
+		if level == "#" {
+			return true
+		}
+		if i >= len(t) || level != "+" && level != t[i] {
+			return false
+		}
+	}
+	return len(f) == len(t)
+}
@@ -0,0 +1,829 @@
+// Package pb converts telematics packets to messages of telematics.proto, Go types of messages
+// are generated by protoc-gen-go, only converters are written by hand
+package pb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative telematics.proto
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/boiledgas/protocol/telematics"
+	"github.com/boiledgas/protocol/telematics/section"
+	"github.com/boiledgas/protocol/telematics/value"
+	"github.com/boiledgas/protocol/utils"
+)
+
+// section types listed in Request.supported
+var supportedTypes = []section.Type{
+	section.SECTION_IDENTIFICATION,
+	section.SECTION_AUTHENTICATION,
+	section.SECTION_SUPPORTED,
+	section.SECTION_MODULE,
+	section.SECTION_MODULE_PROPERTY,
+	section.SECTION_MODULE_PROPERTY_VALUE,
+	section.SECTION_MODULE_PROPERTY_DISABLED,
+	section.SECTION_COMMAND,
+	section.SECTION_COMMAND_ARGUMENT,
+	section.SECTION_COMMAND_EXECUTE,
+}
+
+func isSupportedType(t section.Type) bool {
+	for _, s := range supportedTypes {
+		if s == t {
+			return true
+		}
+	}
+	return false
+}
+
+func FromRequest(r *telematics.Request) (*Request, error) {
+	m := &Request{
+		Sections:  uint32(r.Flags16),
+		Sequence:  uint32(r.Sequence),
+		Timestamp: r.Timestamp,
+	}
+	if r.Has(section.FLAG_IDENTIFICATION) {
+		m.Identification = fromIdentification(&r.Id)
+	}
+	if r.Has(section.FLAG_AUTHENTICATION) {
+		m.Authentication = &Authentication{
+			Flags:      uint32(r.Auth.Flags8),
+			Identifier: r.Auth.Identifier,
+			Secret:     r.Auth.Secret,
+			Nonce:      r.Auth.Nonce,
+			Digest:     r.Auth.Digest,
+		}
+	}
+	if r.Has(section.FLAG_SUPPORTED) {
+		for _, t := range supportedTypes {
+			if r.Sup.IsSupported(t) {
+				m.Supported = append(m.Supported, SectionType(t))
+			}
+		}
+	}
+	if r.HasConfiguration() {
+		conf, err := FromConfiguration(&r.Conf)
+		if err != nil {
+			return nil, err
+		}
+		m.Configuration = conf
+	}
+	for _, s := range r.Values {
+		values, err := fromTypedValues(s.Values, s.Types)
+		if err != nil {
+			return nil, fmt.Errorf("module %v: %v", s.ModuleId, err)
+		}
+		m.Values = append(m.Values, &ModulePropertyValue{ModuleId: uint32(s.ModuleId), Values: values})
+	}
+	for _, s := range r.Executes {
+		args, err := fromTypedValues(s.Arguments, s.Types)
+		if err != nil {
+			return nil, fmt.Errorf("command %v.%v: %v", s.ModuleId, s.CommandId, err)
+		}
+		m.Executes = append(m.Executes, &CommandExecute{ModuleId: uint32(s.ModuleId), CommandId: uint32(s.CommandId), Arguments: args})
+	}
+	for _, s := range r.Disabled {
+		d := &ModulePropertyDisable{DisabledProperties: make(map[uint32]uint32)}
+		for k, v := range s.DisabledProperties {
+			d.DisabledProperties[uint32(k)] = uint32(v)
+		}
+		m.Disabled = append(m.Disabled, d)
+	}
+	return m, nil
+}
+
+func ToRequest(m *Request, r *telematics.Request) (err error) {
+	var sections uint16
+	if sections, err = toUint16(m.Sections, "sections"); err != nil {
+		return
+	}
+	*r = telematics.Request{Flags16: utils.Flags16(sections), Timestamp: m.Timestamp}
+	if r.Sequence, err = toByte(m.Sequence, "sequence"); err != nil {
+		return
+	}
+	if m.Identification != nil {
+		if err = toIdentification(m.Identification, &r.Id); err != nil {
+			return
+		}
+	}
+	if m.Authentication != nil {
+		var flags byte
+		if flags, err = toByte(m.Authentication.Flags, "authentication flags"); err != nil {
+			return
+		}
+		r.Auth = section.Authentication{
+			Flags8:     utils.Flags8(flags),
+			Identifier: m.Authentication.Identifier,
+			Secret:     m.Authentication.Secret,
+			Nonce:      m.Authentication.Nonce,
+			Digest:     m.Authentication.Digest,
+		}
+	}
+	for _, t := range m.Supported {
+		var st byte
+		if st, err = toByte(uint32(t), "section type"); err != nil {
+			return
+		}
+		if !isSupportedType(section.Type(st)) {
+			return fmt.Errorf("section type %v not supported", t)
+		}
+		r.Sup.Support(section.Type(st), true)
+	}
+	if m.Configuration != nil {
+		if err = ToConfiguration(m.Configuration, &r.Conf); err != nil {
+			return
+		}
+	}
+	for _, s := range m.Values {
+		v := section.ModulePropertyValue{}
+		if v.ModuleId, err = toByte(s.ModuleId, "module id"); err != nil {
+			return
+		}
+		if v.Values, v.Types, err = toTypedValues(s.Values); err != nil {
+			return fmt.Errorf("module %v: %v", s.ModuleId, err)
+		}
+		r.Values = append(r.Values, v)
+	}
+	for _, s := range m.Executes {
+		e := section.CommandExecute{}
+		if e.ModuleId, err = toByte(s.ModuleId, "module id"); err != nil {
+			return
+		}
+		if e.CommandId, err = toByte(s.CommandId, "command id"); err != nil {
+			return
+		}
+		if e.Arguments, e.Types, err = toTypedValues(s.Arguments); err != nil {
+			return fmt.Errorf("command %v.%v: %v", s.ModuleId, s.CommandId, err)
+		}
+		r.Executes = append(r.Executes, e)
+	}
+	for _, s := range m.Disabled {
+		d := section.ModulePropertyDisable{DisabledProperties: make(map[byte]byte)}
+		for k, v := range s.DisabledProperties {
+			var key, val byte
+			if key, err = toByte(k, "module id"); err != nil {
+				return
+			}
+			if val, err = toByte(v, "property id"); err != nil {
+				return
+			}
+			d.DisabledProperties[key] = val
+		}
+		r.Disabled = append(r.Disabled, d)
+	}
+	return
+}
+
+func FromResponse(r *telematics.Response) *Response {
+	return &Response{Flags: uint32(r.Flags), Sequence: uint32(r.Sequence), Crc: uint32(r.Crc), Nonce: r.Nonce}
+}
+
+func ToResponse(m *Response, r *telematics.Response) (err error) {
+	var flags byte
+	if flags, err = toByte(m.Flags, "response flags"); err != nil {
+		return
+	}
+	r.Flags = telematics.ResponseFlag(flags)
+	r.Nonce = m.Nonce
+	if r.Sequence, err = toByte(m.Sequence, "sequence"); err != nil {
+		return
+	}
+	r.Crc, err = toByte(m.Crc, "crc")
+	return
+}
+
+func FromConfiguration(c *telematics.Configuration) (*Configuration, error) {
+	m := &Configuration{Hash: uint32(c.Hash)}
+	for _, s := range c.Modules {
+		m.Modules = append(m.Modules, &Module{
+			Flags:       uint32(s.Flags8),
+			Id:          uint32(s.Id),
+			Name:        s.Name,
+			Description: s.Description,
+		})
+	}
+	for _, s := range c.Properties {
+		p := &ModuleProperty{
+			Flags:       uint32(s.Flags8),
+			ModuleId:    uint32(s.ModuleId),
+			Id:          uint32(s.Id),
+			Type:        DataType(s.Type),
+			Access:      uint32(s.Access),
+			Name:        s.Name,
+			Description: s.Desc,
+		}
+		var err error
+		if p.Min, p.Max, p.List, err = fromLimits(s.Type, s.Min, s.Max, s.List); err != nil {
+			return nil, fmt.Errorf("property %v.%v: %v", s.ModuleId, s.Id, err)
+		}
+		m.Properties = append(m.Properties, p)
+	}
+	for _, s := range c.Commands {
+		m.Commands = append(m.Commands, &Command{
+			Flags:       uint32(s.Flags8),
+			ModuleId:    uint32(s.ModuleId),
+			Id:          uint32(s.Id),
+			Name:        s.Name,
+			Description: s.Description,
+		})
+	}
+	for _, s := range c.Arguments {
+		a := &CommandArgument{
+			Flags:       uint32(s.Flags8),
+			ModuleId:    uint32(s.ModuleId),
+			CommandId:   uint32(s.CommandId),
+			Id:          uint32(s.Id),
+			Type:        DataType(s.Type),
+			Required:    uint32(s.Required),
+			Name:        s.Name,
+			Description: s.Desc,
+		}
+		var err error
+		if a.Min, a.Max, a.List, err = fromLimits(s.Type, s.Min, s.Max, s.List); err != nil {
+			return nil, fmt.Errorf("argument %v.%v.%v: %v", s.ModuleId, s.CommandId, s.Id, err)
+		}
+		m.Arguments = append(m.Arguments, a)
+	}
+	return m, nil
+}
+
+func ToConfiguration(m *Configuration, c *telematics.Configuration) (err error) {
+	*c = telematics.Configuration{}
+	if c.Hash, err = toByte(m.Hash, "hash"); err != nil {
+		return
+	}
+	var b [4]byte
+	for _, s := range m.Modules {
+		if err = toBytes(b[:3], "module", s.Flags, s.Id); err != nil {
+			return
+		}
+		c.Modules = append(c.Modules, section.Module{
+			Flags8:      utils.Flags8(b[0]),
+			Id:          b[1],
+			Name:        s.Name,
+			Description: s.Description,
+		})
+	}
+	for _, s := range m.Properties {
+		if err = toBytes(b[:], "property", s.Flags, s.ModuleId, s.Id, s.Access); err != nil {
+			return
+		}
+		p := section.ModuleProperty{
+			Flags8:   utils.Flags8(b[0]),
+			ModuleId: b[1],
+			Id:       b[2],
+			Access:   section.PropertyAccess(b[3]),
+			Name:     s.Name,
+			Desc:     s.Description,
+		}
+		if p.Type, err = toDataType(s.Type); err != nil {
+			return
+		}
+		if p.Min, p.Max, p.List, err = toLimits(p.Type, s.Min, s.Max, s.List); err != nil {
+			return fmt.Errorf("property %v.%v: %v", s.ModuleId, s.Id, err)
+		}
+		c.Properties = append(c.Properties, p)
+	}
+	for _, s := range m.Commands {
+		if err = toBytes(b[:3], "command", s.Flags, s.ModuleId, s.Id); err != nil {
+			return
+		}
+		c.Commands = append(c.Commands, section.Command{
+			Flags8:      utils.Flags8(b[0]),
+			ModuleId:    b[1],
+			Id:          b[2],
+			Name:        s.Name,
+			Description: s.Description,
+		})
+	}
+	for _, s := range m.Arguments {
+		if err = toBytes(b[:], "argument", s.Flags, s.ModuleId, s.CommandId, s.Id); err != nil {
+			return
+		}
+		a := section.CommandArgument{
+			Flags8:    utils.Flags8(b[0]),
+			ModuleId:  b[1],
+			CommandId: b[2],
+			Id:        b[3],
+			Name:      s.Name,
+			Desc:      s.Description,
+		}
+		if a.Required, err = toByte(s.Required, "argument required"); err != nil {
+			return
+		}
+		if a.Type, err = toDataType(s.Type); err != nil {
+			return
+		}
+		if a.Min, a.Max, a.List, err = toLimits(a.Type, s.Min, s.Max, s.List); err != nil {
+			return fmt.Errorf("argument %v.%v.%v: %v", s.ModuleId, s.CommandId, s.Id, err)
+		}
+		c.Arguments = append(c.Arguments, a)
+	}
+	return
+}
+
+// NewValue converts v of data type t, go type of v must be value.Zero(t) type
+func NewValue(t value.DataType, v interface{}) (*Value, error) {
+	zero := value.Zero(t)
+	if zero == nil || reflect.TypeOf(zero) != reflect.TypeOf(v) {
+		return nil, fmt.Errorf("value %v (%T) is not %v", v, v, t)
+	}
+	m := &Value{}
+	switch v := v.(type) {
+	case bool:
+		m.Kind = &Value_BoolValue{BoolValue: v}
+	case int8:
+		m.Kind = &Value_IntValue{IntValue: int64(v)}
+	case int16:
+		m.Kind = &Value_IntValue{IntValue: int64(v)}
+	case int32:
+		m.Kind = &Value_IntValue{IntValue: int64(v)}
+	case int64:
+		m.Kind = &Value_IntValue{IntValue: v}
+	case byte:
+		m.Kind = &Value_UintValue{UintValue: uint64(v)}
+	case uint16:
+		m.Kind = &Value_UintValue{UintValue: uint64(v)}
+	case uint32:
+		m.Kind = &Value_UintValue{UintValue: uint64(v)}
+	case uint64:
+		m.Kind = &Value_UintValue{UintValue: v}
+	case float32:
+		m.Kind = &Value_FloatValue{FloatValue: v}
+	case float64:
+		m.Kind = &Value_DoubleValue{DoubleValue: v}
+	case string:
+		m.Kind = &Value_StringValue{StringValue: v}
+	case []byte:
+		m.Kind = &Value_BytesValue{BytesValue: v}
+	case time.Time:
+		m.Kind = &Value_Timestamp{Timestamp: v.Unix()}
+	case time.Duration:
+		m.Kind = &Value_Timespan{Timespan: int64(v)}
+	case value.Common:
+		m.Kind = &Value_Common{Common: &Common{
+			Flags:      uint32(v.Flags8),
+			State:      v.State,
+			Percentage: uint32(v.Percentage),
+			Value:      v.Value,
+			Meter:      v.Meter,
+		}}
+	case value.IoPort:
+		m.Kind = &Value_IoPort{IoPort: &IoPort{Flags: uint32(v.Flags), State: uint32(v.State)}}
+	case value.Gps:
+		m.Kind = &Value_Gps{Gps: &Gps{
+			Flags:         uint32(v.Flags8),
+			Latitude:      v.Latitude,
+			Longitude:     v.Longitude,
+			Altitude:      int32(v.Altitude),
+			Speed:         uint32(v.Speed),
+			Course:        uint32(v.Course),
+			Satellites:    uint32(v.Sat),
+			Hdop:          v.Hdop,
+			Pdop:          v.Pdop,
+			Fix:           uint32(v.Fix),
+			Valid:         v.Valid,
+			Constellation: uint32(v.Constellation),
+			Extended:      uint32(v.Extended),
+			PreciseSpeed:  v.PreciseSpeed,
+			PreciseCourse: v.PreciseCourse,
+		}}
+	case value.Gsm:
+		m.Kind = &Value_Gsm{Gsm: &Gsm{Mcc: v.MCC, Mnc: v.MNC, Lac: uint32(v.LAC), Cid: uint32(v.CID), Signal: int32(v.Signal)}}
+	case value.CellInfo:
+		info := &CellInfo{Mcc: v.MCC, Mnc: v.MNC, Serving: fromCell(v.Serving)}
+		for _, c := range v.Neighbors {
+			info.Neighbors = append(info.Neighbors, fromCell(c))
+		}
+		m.Kind = &Value_CellInfo{CellInfo: info}
+	case value.Acceleration:
+		m.Kind = &Value_Acceleration{Acceleration: &Acceleration{
+			Flags:    uint32(v.Flags8),
+			X:        v.AxisX,
+			Y:        v.AxisY,
+			Z:        v.AxisZ,
+			Duration: uint32(v.Duration),
+			GyroX:    v.GyroX,
+			GyroY:    v.GyroY,
+			GyroZ:    v.GyroZ,
+		}}
+	case value.Rgb:
+		m.Kind = &Value_Rgb{Rgb: &Rgb{R: uint32(v.R), G: uint32(v.G), B: uint32(v.B)}}
+	}
+	return m, nil
+}
+
+// Data returns go value of data type t, see value.Zero
+func (m *Value) Data(t value.DataType) (res interface{}, err error) {
+	zero := value.Zero(t)
+	if zero == nil {
+		return nil, fmt.Errorf("data type %v not supported", t)
+	}
+	var kind isValue_Kind
+	switch zero.(type) {
+	case bool:
+		kind = &Value_BoolValue{}
+	case int8, int16, int32, int64:
+		kind = &Value_IntValue{}
+	case byte, uint16, uint32, uint64:
+		kind = &Value_UintValue{}
+	case float32:
+		kind = &Value_FloatValue{}
+	case float64:
+		kind = &Value_DoubleValue{}
+	case string:
+		kind = &Value_StringValue{}
+	case []byte:
+		kind = &Value_BytesValue{}
+	case time.Time:
+		kind = &Value_Timestamp{}
+	case time.Duration:
+		kind = &Value_Timespan{}
+	case value.Common:
+		kind = &Value_Common{}
+	case value.IoPort:
+		kind = &Value_IoPort{}
+	case value.Gps:
+		kind = &Value_Gps{}
+	case value.Gsm:
+		kind = &Value_Gsm{}
+	case value.CellInfo:
+		kind = &Value_CellInfo{}
+	case value.Acceleration:
+		kind = &Value_Acceleration{}
+	case value.Rgb:
+		kind = &Value_Rgb{}
+	}
+	if reflect.TypeOf(m.GetKind()) != reflect.TypeOf(kind) {
+		return nil, fmt.Errorf("value kind %v is not %v", m.kind(), t)
+	}
+	if !m.hasMessage() {
+		return nil, fmt.Errorf("value kind %v has no message", m.kind())
+	}
+
+	switch zero.(type) {
+	case bool:
+		return m.GetBoolValue(), nil
+	case int8:
+		return int8(m.GetIntValue()), checkInt(m.GetIntValue(), math.MinInt8, math.MaxInt8, t)
+	case int16:
+		return int16(m.GetIntValue()), checkInt(m.GetIntValue(), math.MinInt16, math.MaxInt16, t)
+	case int32:
+		return int32(m.GetIntValue()), checkInt(m.GetIntValue(), math.MinInt32, math.MaxInt32, t)
+	case int64:
+		return m.GetIntValue(), nil
+	case byte:
+		return byte(m.GetUintValue()), checkUint(m.GetUintValue(), math.MaxUint8, t)
+	case uint16:
+		return uint16(m.GetUintValue()), checkUint(m.GetUintValue(), math.MaxUint16, t)
+	case uint32:
+		return uint32(m.GetUintValue()), checkUint(m.GetUintValue(), math.MaxUint32, t)
+	case uint64:
+		return m.GetUintValue(), nil
+	case float32:
+		return m.GetFloatValue(), nil
+	case float64:
+		return m.GetDoubleValue(), nil
+	case string:
+		return m.GetStringValue(), nil
+	case []byte:
+		return m.GetBytesValue(), nil
+	case time.Time:
+		return time.Unix(m.GetTimestamp(), 0), nil
+	case time.Duration:
+		return time.Duration(m.GetTimespan()), nil
+	case value.Common:
+		return toCommon(m.GetCommon())
+	case value.IoPort:
+		var b [2]byte
+		err = toBytes(b[:], "ioport", m.GetIoPort().Flags, m.GetIoPort().State)
+		return value.IoPort{Flags: b[0], State: b[1]}, err
+	case value.Gps:
+		return toGps(m.GetGps())
+	case value.Gsm:
+		return toGsm(m.GetGsm())
+	case value.CellInfo:
+		return toCellInfo(m.GetCellInfo())
+	case value.Acceleration:
+		return toAcceleration(m.GetAcceleration())
+	case value.Rgb:
+		var b [3]byte
+		err = toBytes(b[:], "rgb", m.GetRgb().R, m.GetRgb().G, m.GetRgb().B)
+		return value.Rgb{R: b[0], G: b[1], B: b[2]}, err
+	}
+	return
+}
+
+// hasMessage reports whether value of message kind has its message set
+func (m *Value) hasMessage() bool {
+	switch k := m.GetKind().(type) {
+	case *Value_Common:
+		return k.Common != nil
+	case *Value_IoPort:
+		return k.IoPort != nil
+	case *Value_Gps:
+		return k.Gps != nil
+	case *Value_Gsm:
+		return k.Gsm != nil
+	case *Value_CellInfo:
+		return k.CellInfo != nil
+	case *Value_Acceleration:
+		return k.Acceleration != nil
+	case *Value_Rgb:
+		return k.Rgb != nil
+	}
+	return true
+}
+
+// kind returns schema name of member set in oneof kind of value
+func (m *Value) kind() string {
+	r := m.ProtoReflect()
+	if fd := r.WhichOneof(r.Descriptor().Oneofs().ByName("kind")); fd != nil {
+		return string(fd.Name())
+	}
+	return "not set"
+}
+
+func fromIdentification(s *section.Identification) *Identification {
+	return &Identification{
+		Flags:      uint32(s.Flags8),
+		Code:       s.Code,
+		CodeText:   s.CodeText,
+		DeviceType: DeviceType(s.Type),
+		Firmware:   int32(s.Firmware),
+		Hardware:   int32(s.Hardware),
+		Hash:       uint32(s.Hash),
+	}
+}
+
+func toIdentification(m *Identification, s *section.Identification) (err error) {
+	var b [3]byte
+	if err = toBytes(b[:], "identification", m.Flags, uint32(m.DeviceType), m.Hash); err != nil {
+		return
+	}
+	if err = checkInt(int64(m.Firmware), math.MinInt16, math.MaxInt16, "firmware"); err != nil {
+		return
+	}
+	if err = checkInt(int64(m.Hardware), math.MinInt16, math.MaxInt16, "hardware"); err != nil {
+		return
+	}
+	*s = section.Identification{
+		Flags8:   utils.Flags8(b[0]),
+		Code:     m.Code,
+		CodeText: m.CodeText,
+		Type:     section.DeviceType(b[1]),
+		Firmware: int16(m.Firmware),
+		Hardware: int16(m.Hardware),
+		Hash:     b[2],
+	}
+	return
+}
+
+func fromLimits(t value.DataType, min, max interface{}, list []value.NameValue) (pmin, pmax *Value, plist []*NameValue, err error) {
+	if min != nil {
+		if pmin, err = NewValue(t, min); err != nil {
+			return
+		}
+	}
+	if max != nil {
+		if pmax, err = NewValue(t, max); err != nil {
+			return
+		}
+	}
+	for _, nv := range list {
+		var v *Value
+		if v, err = NewValue(t, nv.Value); err != nil {
+			return
+		}
+		plist = append(plist, &NameValue{Name: nv.Name, Value: v})
+	}
+	return
+}
+
+func toLimits(t value.DataType, pmin, pmax *Value, plist []*NameValue) (min, max interface{}, list []value.NameValue, err error) {
+	if pmin != nil {
+		if min, err = pmin.Data(t); err != nil {
+			return
+		}
+	}
+	if pmax != nil {
+		if max, err = pmax.Data(t); err != nil {
+			return
+		}
+	}
+	for _, nv := range plist {
+		if nv.Value == nil {
+			err = fmt.Errorf("list value %v not set", nv.Name)
+			return
+		}
+		var v interface{}
+		if v, err = nv.Value.Data(t); err != nil {
+			return
+		}
+		list = append(list, value.NameValue{Name: nv.Name, Value: v})
+	}
+	return
+}
+
+func fromTypedValues(values map[byte]interface{}, types map[byte]value.DataType) (res []*TypedValue, err error) {
+	ids := make([]int, 0, len(values))
+	for id := range values {
+		ids = append(ids, int(id))
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		t, ok := types[byte(id)]
+		if !ok {
+			t = value.TypeOf(values[byte(id)])
+		}
+		var v *Value
+		if v, err = NewValue(t, values[byte(id)]); err != nil {
+			return nil, fmt.Errorf("id %v: %v", id, err)
+		}
+		res = append(res, &TypedValue{Id: uint32(id), Type: DataType(t), Value: v})
+	}
+	return
+}
+
+func toTypedValues(values []*TypedValue) (res map[byte]interface{}, types map[byte]value.DataType, err error) {
+	res = make(map[byte]interface{})
+	types = make(map[byte]value.DataType)
+	for _, tv := range values {
+		var id byte
+		if id, err = toByte(tv.Id, "value id"); err != nil {
+			return
+		}
+		var t value.DataType
+		if t, err = toDataType(tv.Type); err != nil {
+			return
+		}
+		if tv.Value == nil {
+			err = fmt.Errorf("id %v: value not set", id)
+			return
+		}
+		var v interface{}
+		if v, err = tv.Value.Data(t); err != nil {
+			err = fmt.Errorf("id %v: %v", id, err)
+			return
+		}
+		res[id] = v
+		types[id] = t
+	}
+	return
+}
+
+func fromCell(c value.Cell) *Cell {
+	return &Cell{Radio: uint32(c.Radio), Lac: uint32(c.LAC), Tac: uint32(c.TAC), Cid: c.CID, Signal: int32(c.Signal)}
+}
+
+func toCell(m *Cell) (c value.Cell, err error) {
+	if m == nil {
+		return
+	}
+	var radio byte
+	if radio, err = toByte(m.Radio, "cell radio"); err != nil {
+		return
+	}
+	if err = checkUint(uint64(m.Lac), math.MaxUint16, "cell lac"); err != nil {
+		return
+	}
+	if err = checkUint(uint64(m.Tac), math.MaxUint16, "cell tac"); err != nil {
+		return
+	}
+	if err = checkInt(int64(m.Signal), math.MinInt8, math.MaxInt8, "cell signal"); err != nil {
+		return
+	}
+	c = value.Cell{Radio: value.RadioType(radio), LAC: uint16(m.Lac), TAC: uint16(m.Tac), CID: m.Cid, Signal: int8(m.Signal)}
+	return
+}
+
+func toCommon(m *Common) (v value.Common, err error) {
+	var b [2]byte
+	if err = toBytes(b[:], "common", m.Flags, m.Percentage); err != nil {
+		return
+	}
+	v = value.Common{Flags8: utils.Flags8(b[0]), State: m.State, Percentage: b[1], Value: m.Value, Meter: m.Meter}
+	return
+}
+
+func toGps(m *Gps) (v value.Gps, err error) {
+	var b [7]byte
+	if err = toBytes(b[:], "gps", m.Flags, m.Speed, m.Course, m.Satellites, m.Fix, m.Constellation, m.Extended); err != nil {
+		return
+	}
+	if err = checkInt(int64(m.Altitude), math.MinInt16, math.MaxInt16, "gps altitude"); err != nil {
+		return
+	}
+	v = value.Gps{
+		Flags8:        utils.Flags8(b[0]),
+		Latitude:      m.Latitude,
+		Longitude:     m.Longitude,
+		Altitude:      int16(m.Altitude),
+		Speed:         b[1],
+		Course:        b[2],
+		Sat:           b[3],
+		Hdop:          m.Hdop,
+		Pdop:          m.Pdop,
+		Fix:           value.GpsFix(b[4]),
+		Valid:         m.Valid,
+		Constellation: value.Gnss(b[5]),
+		Extended:      utils.Flags8(b[6]),
+		PreciseSpeed:  m.PreciseSpeed,
+		PreciseCourse: m.PreciseCourse,
+	}
+	return
+}
+
+func toGsm(m *Gsm) (v value.Gsm, err error) {
+	if err = checkUint(uint64(m.Lac), math.MaxUint16, "gsm lac"); err != nil {
+		return
+	}
+	if err = checkUint(uint64(m.Cid), math.MaxUint16, "gsm cid"); err != nil {
+		return
+	}
+	if err = checkInt(int64(m.Signal), math.MinInt8, math.MaxInt8, "gsm signal"); err != nil {
+		return
+	}
+	v = value.Gsm{MCC: m.Mcc, MNC: m.Mnc, LAC: uint16(m.Lac), CID: uint16(m.Cid), Signal: int8(m.Signal)}
+	return
+}
+
+func toCellInfo(m *CellInfo) (v value.CellInfo, err error) {
+	v = value.CellInfo{MCC: m.Mcc, MNC: m.Mnc}
+	if v.Serving, err = toCell(m.Serving); err != nil {
+		return
+	}
+	for _, n := range m.Neighbors {
+		var c value.Cell
+		if c, err = toCell(n); err != nil {
+			return
+		}
+		v.Neighbors = append(v.Neighbors, c)
+	}
+	return
+}
+
+func toAcceleration(m *Acceleration) (v value.Acceleration, err error) {
+	var flags byte
+	if flags, err = toByte(m.Flags, "acceleration flags"); err != nil {
+		return
+	}
+	if err = checkUint(uint64(m.Duration), math.MaxUint16, "acceleration duration"); err != nil {
+		return
+	}
+	v = value.Acceleration{
+		Flags8:   utils.Flags8(flags),
+		AxisX:    m.X,
+		AxisY:    m.Y,
+		AxisZ:    m.Z,
+		Duration: uint16(m.Duration),
+		GyroX:    m.GyroX,
+		GyroY:    m.GyroY,
+		GyroZ:    m.GyroZ,
+	}
+	return
+}
+
+func toDataType(t DataType) (value.DataType, error) {
+	if t < 0 || t > math.MaxUint8 {
+		return value.NotSet, fmt.Errorf("data type %d overflow", int32(t))
+	}
+	return value.DataType(t), nil
+}
+
+func toByte(v uint32, name string) (byte, error) {
+	return byte(v), checkUint(uint64(v), math.MaxUint8, name)
+}
+
+func toUint16(v uint32, name string) (uint16, error) {
+	return uint16(v), checkUint(uint64(v), math.MaxUint16, name)
+}
+
+// toBytes narrows values into res, names of errors are prefixed with name
+func toBytes(res []byte, name string, values ...uint32) error {
+	for i, v := range values {
+		if v > math.MaxUint8 {
+			return fmt.Errorf("%v field %v: %v overflows byte", name, i, v)
+		}
+		res[i] = byte(v)
+	}
+	return nil
+}
+
+func checkUint(v uint64, max uint64, name interface{}) error {
+	if v > max {
+		return fmt.Errorf("%v: %v overflows %v", name, v, max)
+	}
+	return nil
+}
+
+func checkInt(v int64, min int64, max int64, name interface{}) error {
+	if v < min || v > max {
+		return fmt.Errorf("%v: %v out of range [%v, %v]", name, v, min, max)
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package pb
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boiledgas/protocol/telematics"
+	"github.com/boiledgas/protocol/telematics/section"
+	"github.com/boiledgas/protocol/telematics/value"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func Test_ResponseWire(t *testing.T) {
+	res := telematics.Response{Flags: telematics.RESPONSE_ERROR, Sequence: 7}
+	data, err := proto.Marshal(FromResponse(&res))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, []byte{0x08, 0x80, 0x01, 0x10, 0x07}) {
+		t.Errorf("response wire wrong: % x", data)
+	}
+	m := Response{}
+	if err := proto.Unmarshal(data, &m); err != nil {
+		t.Fatal(err)
+	}
+	back := telematics.Response{}
+	if err := ToResponse(&m, &back); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(back, res) {
+		t.Errorf("response wrong: %v != %v", back, res)
+	}
+}
+
+func Test_RequestRoundTrip(t *testing.T) {
+	m := section.Module{Id: 1, Name: "sensors"}
+	m.Set(section.MODULE_FLAGS_NAME, true)
+	p := section.ModuleProperty{ModuleId: 1, Id: 2, Type: value.Temperature, Min: float32(-40), Max: float32(85)}
+	p.Set(section.MODULE_PROPERTY_FLAGS_MIN, true)
+	p.Set(section.MODULE_PROPERTY_FLAGS_MAX, true)
+	ca := section.CommandArgument{ModuleId: 1, CommandId: 1, Id: 1, Type: value.Byte, List: []value.NameValue{{Name: "Soft", Value: byte(1)}, {Name: "Hard", Value: byte(2)}}}
+	ca.Set(section.COMMAND_ARGUMENT_FLAGS_LIST, true)
+
+	gps := value.Gps{Latitude: 55.75, Longitude: 37.61, Altitude: -12, Sat: 9, Fix: value.GPS_FIX_3D}
+	gps.Set(value.GPS_FLAG_LATLNG, true)
+	gps.Set(value.GPS_FLAG_ALTITUDE, true)
+	cell := value.CellInfo{MCC: "250", MNC: "01", Serving: value.Cell{Radio: value.RADIO_LTE, TAC: 7, CID: 100000, Signal: -90}}
+	cell.Neighbors = []value.Cell{{Radio: value.RADIO_GSM, LAC: 3, CID: 5, Signal: -101}}
+
+	req := telematics.Request{Sequence: 9, Timestamp: 1500000000}
+	req.Id = section.Identification{CodeText: "device1", Type: section.DEVICETYPE_CAR, Firmware: -2}
+	req.Id.Set(section.IDENTIFICATION_FLAGS_CODETEXT, true)
+	req.Id.Set(section.IDENTIFICATION_FLAGS_DEVICETYPE, true)
+	req.Id.Set(section.IDENTIFICATION_FLAGS_FIRMWARE, true)
+	req.Set(section.FLAG_IDENTIFICATION, true)
+	req.Sup.Support(section.SECTION_MODULE_PROPERTY_VALUE, true)
+	req.Sup.Support(section.SECTION_COMMAND_EXECUTE, true)
+	req.Set(section.FLAG_SUPPORTED, true)
+	req.Conf = telematics.Configuration{
+		Hash:       3,
+		Modules:    []section.Module{m},
+		Properties: []section.ModuleProperty{p},
+		Arguments:  []section.CommandArgument{ca},
+	}
+	req.Set(section.FLAG_MODULE, true)
+	req.Set(section.FLAG_MODULE_PROPERTY, true)
+	req.Set(section.FLAG_COMMAND_ARGUMENT, true)
+	req.Values = []section.ModulePropertyValue{{
+		ModuleId: 1,
+		Values: map[byte]interface{}{
+			1: gps,
+			2: float32(21.5),
+			3: cell,
+			4: 90 * time.Second,
+			5: time.Unix(1500000000, 0),
+			6: int32(-70000),
+			7: []byte{1, 2, 3},
+		},
+		Types: map[byte]value.DataType{1: value.GPS, 2: value.Temperature, 3: value.CELLINFO, 4: value.Timespan, 5: value.Timestamp, 6: value.Int, 7: value.Binary},
+	}}
+	req.Set(section.FLAG_MODULE_PROPERTY_VALUE, true)
+	req.Executes = []section.CommandExecute{{
+		ModuleId:  1,
+		CommandId: 1,
+		Arguments: map[byte]interface{}{1: byte(2)},
+		Types:     map[byte]value.DataType{1: value.Byte},
+	}}
+	req.Set(section.FLAG_COMMAND_EXECUTE, true)
+	req.Disabled = []section.ModulePropertyDisable{{DisabledProperties: map[byte]byte{1: 2}}}
+	req.Set(section.FLAG_MODULE_PROPERTY_DISABLED, true)
+
+	msg, err := FromRequest(&req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := Request{}
+	if err := proto.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(msg, &decoded) {
+		t.Errorf("message wrong: %v != %v", msg, &decoded)
+	}
+	res := telematics.Request{}
+	if err := ToRequest(&decoded, &res); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(req, res) {
+		t.Errorf("request wrong: %v != %v", req, res)
+	}
+}
+
+func Test_ValueOverflow(t *testing.T) {
+	v := &Value{Kind: &Value_UintValue{UintValue: 300}}
+	if _, err := v.Data(value.Byte); err == nil {
+		t.Error("byte overflow not detected")
+	}
+	if _, err := v.Data(value.Float); err == nil {
+		t.Error("kind mismatch not detected")
+	}
+	if _, err := NewValue(value.Byte, 300); err == nil {
+		t.Error("go type mismatch not detected")
+	}
+}
+
+func Test_ValueWithoutMessage(t *testing.T) {
+	for _, c := range []struct {
+		kind isValue_Kind
+		t    value.DataType
+	}{{&Value_Common{}, value.COMMON}, {&Value_IoPort{}, value.IOPort}, {&Value_Gps{}, value.GPS}, {&Value_CellInfo{}, value.CELLINFO}, {&Value_Rgb{}, value.RGB}} {
+		v := &Value{Kind: c.kind}
+		if _, err := v.Data(c.t); err == nil {
+			t.Errorf("%v without message accepted", v.kind())
+		}
+	}
+	if _, err := (&Value{}).Data(value.Bool); err == nil {
+		t.Error("value without kind accepted")
+	}
+}
+
+func Test_Enums(t *testing.T) {
+	normalize := func(name string) string {
+		return strings.ToLower(strings.Replace(name, "_", "", -1))
+	}
+	check := func(values protoreflect.EnumValueDescriptors, prefix string, name func(n int32) string) {
+		for i := 0; i < values.Len(); i++ {
+			v := values.Get(i)
+			if normalize(strings.TrimPrefix(string(v.Name()), prefix)) != normalize(name(int32(v.Number()))) {
+				t.Errorf("%v = %v is %v in go", v.Name(), v.Number(), name(int32(v.Number())))
+			}
+		}
+	}
+	check(SectionType(0).Descriptor().Values(), "SECTION_", func(n int32) string { return section.Type(n).String() })
+	check(DeviceType(0).Descriptor().Values(), "DEVICE_TYPE_", func(n int32) string { return section.DeviceType(n).String() })
+	check(DataType(0).Descriptor().Values(), "DATA_TYPE_", func(n int32) string { return value.DataType(n).String() })
+}
@@ -0,0 +1,2861 @@
+// Telematics protocol messages for publishing decoded packets.
+// Enum values and flags keep their wire protocol numbers.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: telematics.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SectionType int32
+
+const (
+	SectionType_SECTION_UNKNOWN                  SectionType = 0
+	SectionType_SECTION_IDENTIFICATION           SectionType = 1
+	SectionType_SECTION_AUTHENTICATION           SectionType = 2
+	SectionType_SECTION_MODULE                   SectionType = 3
+	SectionType_SECTION_MODULE_PROPERTY          SectionType = 4
+	SectionType_SECTION_MODULE_PROPERTY_VALUE    SectionType = 5
+	SectionType_SECTION_MODULE_PROPERTY_DISABLED SectionType = 6
+	SectionType_SECTION_COMMAND                  SectionType = 7
+	SectionType_SECTION_COMMAND_ARGUMENT         SectionType = 8
+	SectionType_SECTION_COMMAND_EXECUTE          SectionType = 9
+	SectionType_SECTION_SUPPORTED                SectionType = 10
+)
+
+// Enum value maps for SectionType.
+var (
+	SectionType_name = map[int32]string{
+		0:  "SECTION_UNKNOWN",
+		1:  "SECTION_IDENTIFICATION",
+		2:  "SECTION_AUTHENTICATION",
+		3:  "SECTION_MODULE",
+		4:  "SECTION_MODULE_PROPERTY",
+		5:  "SECTION_MODULE_PROPERTY_VALUE",
+		6:  "SECTION_MODULE_PROPERTY_DISABLED",
+		7:  "SECTION_COMMAND",
+		8:  "SECTION_COMMAND_ARGUMENT",
+		9:  "SECTION_COMMAND_EXECUTE",
+		10: "SECTION_SUPPORTED",
+	}
+	SectionType_value = map[string]int32{
+		"SECTION_UNKNOWN":                  0,
+		"SECTION_IDENTIFICATION":           1,
+		"SECTION_AUTHENTICATION":           2,
+		"SECTION_MODULE":                   3,
+		"SECTION_MODULE_PROPERTY":          4,
+		"SECTION_MODULE_PROPERTY_VALUE":    5,
+		"SECTION_MODULE_PROPERTY_DISABLED": 6,
+		"SECTION_COMMAND":                  7,
+		"SECTION_COMMAND_ARGUMENT":         8,
+		"SECTION_COMMAND_EXECUTE":          9,
+		"SECTION_SUPPORTED":                10,
+	}
+)
+
+func (x SectionType) Enum() *SectionType {
+	p := new(SectionType)
+	*p = x
+	return p
+}
+
+func (x SectionType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SectionType) Descriptor() protoreflect.EnumDescriptor {
+	return file_telematics_proto_enumTypes[0].Descriptor()
+}
+
+func (SectionType) Type() protoreflect.EnumType {
+	return &file_telematics_proto_enumTypes[0]
+}
+
+func (x SectionType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SectionType.Descriptor instead.
+func (SectionType) EnumDescriptor() ([]byte, []int) {
+	return file_telematics_proto_rawDescGZIP(), []int{0}
+}
+
+type DeviceType int32
+
+const (
+	DeviceType_DEVICE_TYPE_NOT_SPECIFIED DeviceType = 0
+	DeviceType_DEVICE_TYPE_APPLICATION   DeviceType = 1
+	DeviceType_DEVICE_TYPE_PERSONAL      DeviceType = 2
+	DeviceType_DEVICE_TYPE_STATIONARY    DeviceType = 3
+	DeviceType_DEVICE_TYPE_CAR           DeviceType = 4
+	DeviceType_DEVICE_TYPE_CAR_OBD       DeviceType = 5
+	DeviceType_DEVICE_TYPE_CAR_SOCKET    DeviceType = 6
+	DeviceType_DEVICE_TYPE_CAR_BEACON    DeviceType = 7
+)
+
+// Enum value maps for DeviceType.
+var (
+	DeviceType_name = map[int32]string{
+		0: "DEVICE_TYPE_NOT_SPECIFIED",
+		1: "DEVICE_TYPE_APPLICATION",
+		2: "DEVICE_TYPE_PERSONAL",
+		3: "DEVICE_TYPE_STATIONARY",
+		4: "DEVICE_TYPE_CAR",
+		5: "DEVICE_TYPE_CAR_OBD",
+		6: "DEVICE_TYPE_CAR_SOCKET",
+		7: "DEVICE_TYPE_CAR_BEACON",
+	}
+	DeviceType_value = map[string]int32{
+		"DEVICE_TYPE_NOT_SPECIFIED": 0,
+		"DEVICE_TYPE_APPLICATION":   1,
+		"DEVICE_TYPE_PERSONAL":      2,
+		"DEVICE_TYPE_STATIONARY":    3,
+		"DEVICE_TYPE_CAR":           4,
+		"DEVICE_TYPE_CAR_OBD":       5,
+		"DEVICE_TYPE_CAR_SOCKET":    6,
+		"DEVICE_TYPE_CAR_BEACON":    7,
+	}
+)
+
+func (x DeviceType) Enum() *DeviceType {
+	p := new(DeviceType)
+	*p = x
+	return p
+}
+
+func (x DeviceType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeviceType) Descriptor() protoreflect.EnumDescriptor {
+	return file_telematics_proto_enumTypes[1].Descriptor()
+}
+
+func (DeviceType) Type() protoreflect.EnumType {
+	return &file_telematics_proto_enumTypes[1]
+}
+
+func (x DeviceType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeviceType.Descriptor instead.
+func (DeviceType) EnumDescriptor() ([]byte, []int) {
+	return file_telematics_proto_rawDescGZIP(), []int{1}
+}
+
+type DataType int32
+
+const (
+	DataType_DATA_TYPE_NOT_SET       DataType = 0
+	DataType_DATA_TYPE_BOOL          DataType = 1
+	DataType_DATA_TYPE_SBYTE         DataType = 2
+	DataType_DATA_TYPE_BYTE          DataType = 3
+	DataType_DATA_TYPE_SHORT         DataType = 4
+	DataType_DATA_TYPE_USHORT        DataType = 5
+	DataType_DATA_TYPE_INT24         DataType = 6
+	DataType_DATA_TYPE_UINT24        DataType = 7
+	DataType_DATA_TYPE_INT           DataType = 8
+	DataType_DATA_TYPE_UINT          DataType = 9
+	DataType_DATA_TYPE_LONG          DataType = 10
+	DataType_DATA_TYPE_ULONG         DataType = 11
+	DataType_DATA_TYPE_FLOAT         DataType = 12
+	DataType_DATA_TYPE_DOUBLE        DataType = 13
+	DataType_DATA_TYPE_ARRAY         DataType = 14
+	DataType_DATA_TYPE_STRING        DataType = 15
+	DataType_DATA_TYPE_BINARY        DataType = 16
+	DataType_DATA_TYPE_ID            DataType = 17
+	DataType_DATA_TYPE_NAME          DataType = 18
+	DataType_DATA_TYPE_COMMON        DataType = 19
+	DataType_DATA_TYPE_OPEN_CLOSE    DataType = 20
+	DataType_DATA_TYPE_ON_OFF        DataType = 21
+	DataType_DATA_TYPE_YES_NO        DataType = 22
+	DataType_DATA_TYPE_IO_PIN        DataType = 23
+	DataType_DATA_TYPE_TAMPER        DataType = 24
+	DataType_DATA_TYPE_BREAK         DataType = 25
+	DataType_DATA_TYPE_IGNITION      DataType = 26
+	DataType_DATA_TYPE_MOVEMENT      DataType = 27
+	DataType_DATA_TYPE_ALARM         DataType = 28
+	DataType_DATA_TYPE_PANIC         DataType = 29
+	DataType_DATA_TYPE_SMOKE         DataType = 30
+	DataType_DATA_TYPE_FREQUENCY     DataType = 31
+	DataType_DATA_TYPE_ANALOG        DataType = 32
+	DataType_DATA_TYPE_TIMESTAMP     DataType = 33
+	DataType_DATA_TYPE_TIMESPAN      DataType = 34
+	DataType_DATA_TYPE_TEMPERATURE   DataType = 35
+	DataType_DATA_TYPE_HUMIDITY      DataType = 36
+	DataType_DATA_TYPE_PRESSURE      DataType = 37
+	DataType_DATA_TYPE_WEIGHT        DataType = 38
+	DataType_DATA_TYPE_LOUDNESS      DataType = 39
+	DataType_DATA_TYPE_ANGLE         DataType = 40
+	DataType_DATA_TYPE_SPEED         DataType = 41
+	DataType_DATA_TYPE_MILEAGE       DataType = 42
+	DataType_DATA_TYPE_RPM           DataType = 43
+	DataType_DATA_TYPE_ENGINE_HOURS  DataType = 44
+	DataType_DATA_TYPE_DISTANCE      DataType = 45
+	DataType_DATA_TYPE_IDENTIFY      DataType = 46
+	DataType_DATA_TYPE_VOLTAGE       DataType = 47
+	DataType_DATA_TYPE_BATTERY       DataType = 48
+	DataType_DATA_TYPE_POWER         DataType = 49
+	DataType_DATA_TYPE_LIQUID        DataType = 50
+	DataType_DATA_TYPE_WATER         DataType = 51
+	DataType_DATA_TYPE_FUEL          DataType = 52
+	DataType_DATA_TYPE_GAS           DataType = 53
+	DataType_DATA_TYPE_IO_PORT       DataType = 54
+	DataType_DATA_TYPE_GPS           DataType = 55
+	DataType_DATA_TYPE_GSM           DataType = 56
+	DataType_DATA_TYPE_ACCELERATION  DataType = 57
+	DataType_DATA_TYPE_DATA_SAMPLING DataType = 58
+	DataType_DATA_TYPE_SOUND         DataType = 59
+	DataType_DATA_TYPE_ACCIDENT      DataType = 60
+	DataType_DATA_TYPE_TEXT_MESSAGE  DataType = 61
+	DataType_DATA_TYPE_ILLUMINANCE   DataType = 62
+	DataType_DATA_TYPE_RADIATION     DataType = 63
+	DataType_DATA_TYPE_RGB           DataType = 65
+	DataType_DATA_TYPE_CELL_INFO     DataType = 66
+)
+
+// Enum value maps for DataType.
+var (
+	DataType_name = map[int32]string{
+		0:  "DATA_TYPE_NOT_SET",
+		1:  "DATA_TYPE_BOOL",
+		2:  "DATA_TYPE_SBYTE",
+		3:  "DATA_TYPE_BYTE",
+		4:  "DATA_TYPE_SHORT",
+		5:  "DATA_TYPE_USHORT",
+		6:  "DATA_TYPE_INT24",
+		7:  "DATA_TYPE_UINT24",
+		8:  "DATA_TYPE_INT",
+		9:  "DATA_TYPE_UINT",
+		10: "DATA_TYPE_LONG",
+		11: "DATA_TYPE_ULONG",
+		12: "DATA_TYPE_FLOAT",
+		13: "DATA_TYPE_DOUBLE",
+		14: "DATA_TYPE_ARRAY",
+		15: "DATA_TYPE_STRING",
+		16: "DATA_TYPE_BINARY",
+		17: "DATA_TYPE_ID",
+		18: "DATA_TYPE_NAME",
+		19: "DATA_TYPE_COMMON",
+		20: "DATA_TYPE_OPEN_CLOSE",
+		21: "DATA_TYPE_ON_OFF",
+		22: "DATA_TYPE_YES_NO",
+		23: "DATA_TYPE_IO_PIN",
+		24: "DATA_TYPE_TAMPER",
+		25: "DATA_TYPE_BREAK",
+		26: "DATA_TYPE_IGNITION",
+		27: "DATA_TYPE_MOVEMENT",
+		28: "DATA_TYPE_ALARM",
+		29: "DATA_TYPE_PANIC",
+		30: "DATA_TYPE_SMOKE",
+		31: "DATA_TYPE_FREQUENCY",
+		32: "DATA_TYPE_ANALOG",
+		33: "DATA_TYPE_TIMESTAMP",
+		34: "DATA_TYPE_TIMESPAN",
+		35: "DATA_TYPE_TEMPERATURE",
+		36: "DATA_TYPE_HUMIDITY",
+		37: "DATA_TYPE_PRESSURE",
+		38: "DATA_TYPE_WEIGHT",
+		39: "DATA_TYPE_LOUDNESS",
+		40: "DATA_TYPE_ANGLE",
+		41: "DATA_TYPE_SPEED",
+		42: "DATA_TYPE_MILEAGE",
+		43: "DATA_TYPE_RPM",
+		44: "DATA_TYPE_ENGINE_HOURS",
+		45: "DATA_TYPE_DISTANCE",
+		46: "DATA_TYPE_IDENTIFY",
+		47: "DATA_TYPE_VOLTAGE",
+		48: "DATA_TYPE_BATTERY",
+		49: "DATA_TYPE_POWER",
+		50: "DATA_TYPE_LIQUID",
+		51: "DATA_TYPE_WATER",
+		52: "DATA_TYPE_FUEL",
+		53: "DATA_TYPE_GAS",
+		54: "DATA_TYPE_IO_PORT",
+		55: "DATA_TYPE_GPS",
+		56: "DATA_TYPE_GSM",
+		57: "DATA_TYPE_ACCELERATION",
+		58: "DATA_TYPE_DATA_SAMPLING",
+		59: "DATA_TYPE_SOUND",
+		60: "DATA_TYPE_ACCIDENT",
+		61: "DATA_TYPE_TEXT_MESSAGE",
+		62: "DATA_TYPE_ILLUMINANCE",
+		63: "DATA_TYPE_RADIATION",
+		65: "DATA_TYPE_RGB",
+		66: "DATA_TYPE_CELL_INFO",
+	}
+	DataType_value = map[string]int32{
+		"DATA_TYPE_NOT_SET":       0,
+		"DATA_TYPE_BOOL":          1,
+		"DATA_TYPE_SBYTE":         2,
+		"DATA_TYPE_BYTE":          3,
+		"DATA_TYPE_SHORT":         4,
+		"DATA_TYPE_USHORT":        5,
+		"DATA_TYPE_INT24":         6,
+		"DATA_TYPE_UINT24":        7,
+		"DATA_TYPE_INT":           8,
+		"DATA_TYPE_UINT":          9,
+		"DATA_TYPE_LONG":          10,
+		"DATA_TYPE_ULONG":         11,
+		"DATA_TYPE_FLOAT":         12,
+		"DATA_TYPE_DOUBLE":        13,
+		"DATA_TYPE_ARRAY":         14,
+		"DATA_TYPE_STRING":        15,
+		"DATA_TYPE_BINARY":        16,
+		"DATA_TYPE_ID":            17,
+		"DATA_TYPE_NAME":          18,
+		"DATA_TYPE_COMMON":        19,
+		"DATA_TYPE_OPEN_CLOSE":    20,
+		"DATA_TYPE_ON_OFF":        21,
+		"DATA_TYPE_YES_NO":        22,
+		"DATA_TYPE_IO_PIN":        23,
+		"DATA_TYPE_TAMPER":        24,
+		"DATA_TYPE_BREAK":         25,
+		"DATA_TYPE_IGNITION":      26,
+		"DATA_TYPE_MOVEMENT":      27,
+		"DATA_TYPE_ALARM":         28,
+		"DATA_TYPE_PANIC":         29,
+		"DATA_TYPE_SMOKE":         30,
+		"DATA_TYPE_FREQUENCY":     31,
+		"DATA_TYPE_ANALOG":        32,
+		"DATA_TYPE_TIMESTAMP":     33,
+		"DATA_TYPE_TIMESPAN":      34,
+		"DATA_TYPE_TEMPERATURE":   35,
+		"DATA_TYPE_HUMIDITY":      36,
+		"DATA_TYPE_PRESSURE":      37,
+		"DATA_TYPE_WEIGHT":        38,
+		"DATA_TYPE_LOUDNESS":      39,
+		"DATA_TYPE_ANGLE":         40,
+		"DATA_TYPE_SPEED":         41,
+		"DATA_TYPE_MILEAGE":       42,
+		"DATA_TYPE_RPM":           43,
+		"DATA_TYPE_ENGINE_HOURS":  44,
+		"DATA_TYPE_DISTANCE":      45,
+		"DATA_TYPE_IDENTIFY":      46,
+		"DATA_TYPE_VOLTAGE":       47,
+		"DATA_TYPE_BATTERY":       48,
+		"DATA_TYPE_POWER":         49,
+		"DATA_TYPE_LIQUID":        50,
+		"DATA_TYPE_WATER":         51,
+		"DATA_TYPE_FUEL":          52,
+		"DATA_TYPE_GAS":           53,
+		"DATA_TYPE_IO_PORT":       54,
+		"DATA_TYPE_GPS":           55,
+		"DATA_TYPE_GSM":           56,
+		"DATA_TYPE_ACCELERATION":  57,
+		"DATA_TYPE_DATA_SAMPLING": 58,
+		"DATA_TYPE_SOUND":         59,
+		"DATA_TYPE_ACCIDENT":      60,
+		"DATA_TYPE_TEXT_MESSAGE":  61,
+		"DATA_TYPE_ILLUMINANCE":   62,
+		"DATA_TYPE_RADIATION":     63,
+		"DATA_TYPE_RGB":           65,
+		"DATA_TYPE_CELL_INFO":     66,
+	}
+)
+
+func (x DataType) Enum() *DataType {
+	p := new(DataType)
+	*p = x
+	return p
+}
+
+func (x DataType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DataType) Descriptor() protoreflect.EnumDescriptor {
+	return file_telematics_proto_enumTypes[2].Descriptor()
+}
+
+func (DataType) Type() protoreflect.EnumType {
+	return &file_telematics_proto_enumTypes[2]
+}
+
+func (x DataType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DataType.Descriptor instead.
+func (DataType) EnumDescriptor() ([]byte, []int) {
+	return file_telematics_proto_rawDescGZIP(), []int{2}
+}
+
+type Request struct {
+	state          protoimpl.MessageState   `protogen:"open.v1"`
+	Sections       uint32                   `protobuf:"varint,1,opt,name=sections,proto3" json:"sections,omitempty"` // section flags, section.FLAG_*
+	Sequence       uint32                   `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Timestamp      int32                    `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Identification *Identification          `protobuf:"bytes,4,opt,name=identification,proto3" json:"identification,omitempty"`
+	Authentication *Authentication          `protobuf:"bytes,5,opt,name=authentication,proto3" json:"authentication,omitempty"`
+	Supported      []SectionType            `protobuf:"varint,6,rep,packed,name=supported,proto3,enum=telematics.SectionType" json:"supported,omitempty"`
+	Configuration  *Configuration           `protobuf:"bytes,7,opt,name=configuration,proto3" json:"configuration,omitempty"`
+	Values         []*ModulePropertyValue   `protobuf:"bytes,8,rep,name=values,proto3" json:"values,omitempty"`
+	Executes       []*CommandExecute        `protobuf:"bytes,9,rep,name=executes,proto3" json:"executes,omitempty"`
+	Disabled       []*ModulePropertyDisable `protobuf:"bytes,10,rep,name=disabled,proto3" json:"disabled,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Request) Reset() {
+	*x = Request{}
+	mi := &file_telematics_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Request) ProtoMessage() {}
+
+func (x *Request) ProtoReflect() protoreflect.Message {
+	mi := &file_telematics_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Request.ProtoReflect.Descriptor instead.
+func (*Request) Descriptor() ([]byte, []int) {
+	return file_telematics_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Request) GetSections() uint32 {
+	if x != nil {
+		return x.Sections
+	}
+	return 0
+}
+
+func (x *Request) GetSequence() uint32 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *Request) GetTimestamp() int32 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *Request) GetIdentification() *Identification {
+	if x != nil {
+		return x.Identification
+	}
+	return nil
+}
+
+func (x *Request) GetAuthentication() *Authentication {
+	if x != nil {
+		return x.Authentication
+	}
+	return nil
+}
+
+func (x *Request) GetSupported() []SectionType {
+	if x != nil {
+		return x.Supported
+	}
+	return nil
+}
+
+func (x *Request) GetConfiguration() *Configuration {
+	if x != nil {
+		return x.Configuration
+	}
+	return nil
+}
+
+func (x *Request) GetValues() []*ModulePropertyValue {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *Request) GetExecutes() []*CommandExecute {
+	if x != nil {
+		return x.Executes
+	}
+	return nil
+}
+
+func (x *Request) GetDisabled() []*ModulePropertyDisable {
+	if x != nil {
+		return x.Disabled
+	}
+	return nil
+}
+
+type Response struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Flags         uint32                 `protobuf:"varint,1,opt,name=flags,proto3" json:"flags,omitempty"`
+	Sequence      uint32                 `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Crc           uint32                 `protobuf:"varint,3,opt,name=crc,proto3" json:"crc,omitempty"`
+	Nonce         []byte                 `protobuf:"bytes,4,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Response) Reset() {
+	*x = Response{}
+	mi := &file_telematics_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Response) ProtoMessage() {}
+
+func (x *Response) ProtoReflect() protoreflect.Message {
+	mi := &file_telematics_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Response.ProtoReflect.Descriptor instead.
+func (*Response) Descriptor() ([]byte, []int) {
+	return file_telematics_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Response) GetFlags() uint32 {
+	if x != nil {
+		return x.Flags
+	}
+	return 0
+}
+
+func (x *Response) GetSequence() uint32 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *Response) GetCrc() uint32 {
+	if x != nil {
+		return x.Crc
+	}
+	return 0
+}
+
+func (x *Response) GetNonce() []byte {
+	if x != nil {
+		return x.Nonce
+	}
+	return nil
+}
+
+type Configuration struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hash          uint32                 `protobuf:"varint,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Modules       []*Module              `protobuf:"bytes,2,rep,name=modules,proto3" json:"modules,omitempty"`
+	Properties    []*ModuleProperty      `protobuf:"bytes,3,rep,name=properties,proto3" json:"properties,omitempty"`
+	Commands      []*Command             `protobuf:"bytes,4,rep,name=commands,proto3" json:"commands,omitempty"`
+	Arguments     []*CommandArgument     `protobuf:"bytes,5,rep,name=arguments,proto3" json:"arguments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Configuration) Reset() {
+	*x = Configuration{}
+	mi := &file_telematics_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Configuration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Configuration) ProtoMessage() {}
+
+func (x *Configuration) ProtoReflect() protoreflect.Message {
+	mi := &file_telematics_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Configuration.ProtoReflect.Descriptor instead.
+func (*Configuration) Descriptor() ([]byte, []int) {
+	return file_telematics_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Configuration) GetHash() uint32 {
+	if x != nil {
+		return x.Hash
+	}
+	return 0
+}
+
+func (x *Configuration) GetModules() []*Module {
+	if x != nil {
+		return x.Modules
+	}
+	return nil
+}
+
+func (x *Configuration) GetProperties() []*ModuleProperty {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+func (x *Configuration) GetCommands() []*Command {
+	if x != nil {
+		return x.Commands
+	}
+	return nil
+}
+
+func (x *Configuration) GetArguments() []*CommandArgument {
+	if x != nil {
+		return x.Arguments
+	}
+	return nil
+}
+
+type Identification struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Flags         uint32                 `protobuf:"varint,1,opt,name=flags,proto3" json:"flags,omitempty"`
+	Code          uint32                 `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
+	CodeText      string                 `protobuf:"bytes,3,opt,name=code_text,json=codeText,proto3" json:"code_text,omitempty"`
+	DeviceType    DeviceType             `protobuf:"varint,4,opt,name=device_type,json=deviceType,proto3,enum=telematics.DeviceType" json:"device_type,omitempty"`
+	Firmware      int32                  `protobuf:"varint,5,opt,name=firmware,proto3" json:"firmware,omitempty"`
+	Hardware      int32                  `protobuf:"varint,6,opt,name=hardware,proto3" json:"hardware,omitempty"`
+	Hash          uint32                 `protobuf:"varint,7,opt,name=hash,proto3" json:"hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Identification) Reset() {
+	*x = Identification{}
+	mi := &file_telematics_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Identification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Identification) ProtoMessage() {}
+
+func (x *Identification) ProtoReflect() protoreflect.Message {
+	mi := &file_telematics_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Identification.ProtoReflect.Descriptor instead.
+func (*Identification) Descriptor() ([]byte, []int) {
+	return file_telematics_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Identification) GetFlags() uint32 {
+	if x != nil {
+		return x.Flags
+	}
+	return 0
+}
+
+func (x *Identification) GetCode() uint32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *Identification) GetCodeText() string {
+	if x != nil {
+		return x.CodeText
+	}
+	return ""
+}
+
+func (x *Identification) GetDeviceType() DeviceType {
+	if x != nil {
+		return x.DeviceType
+	}
+	return DeviceType_DEVICE_TYPE_NOT_SPECIFIED
+}
+
+func (x *Identification) GetFirmware() int32 {
+	if x != nil {
+		return x.Firmware
+	}
+	return 0
+}
+
+func (x *Identification) GetHardware() int32 {
+	if x != nil {
+		return x.Hardware
+	}
+	return 0
+}
+
+func (x *Identification) GetHash() uint32 {
+	if x != nil {
+		return x.Hash
+	}
+	return 0
+}
+
+type Authentication struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Flags         uint32                 `protobuf:"varint,1,opt,name=flags,proto3" json:"flags,omitempty"`
+	Identifier    string                 `protobuf:"bytes,2,opt,name=identifier,proto3" json:"identifier,omitempty"`
+	Secret        []byte                 `protobuf:"bytes,3,opt,name=secret,proto3" json:"secret,omitempty"`
+	Nonce         []byte                 `protobuf:"bytes,4,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Digest        []byte                 `protobuf:"bytes,5,opt,name=digest,proto3" json:"digest,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Authentication) Reset() {
+	*x = Authentication{}
+	mi := &file_telematics_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Authentication) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Authentication) ProtoMessage() {}
+
+func (x *Authentication) ProtoReflect() protoreflect.Message {
+	mi := &file_telematics_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Authentication.ProtoReflect.Descriptor instead.
+func (*Authentication) Descriptor() ([]byte, []int) {
+	return file_telematics_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Authentication) GetFlags() uint32 {
+	if x != nil {
+		return x.Flags
+	}
+	return 0
+}
+
+func (x *Authentication) GetIdentifier() string {
+	if x != nil {
+		return x.Identifier
+	}
+	return ""
+}
+
+func (x *Authentication) GetSecret() []byte {
+	if x != nil {
+		return x.Secret
+	}
+	return nil
+}
+
+func (x *Authentication) GetNonce() []byte {
+	if x != nil {
+		return x.Nonce
+	}
+	return nil
+}
+
+func (x *Authentication) GetDigest() []byte {
+	if x != nil {
+		return x.Digest
+	}
+	return nil
+}
+
+type Module struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Flags         uint32                 `protobuf:"varint,1,opt,name=flags,proto3" json:"flags,omitempty"`
+	Id            uint32                 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Module) Reset() {
+	*x = Module{}
+	mi := &file_telematics_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Module) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Module) ProtoMessage() {}
+
+func (x *Module) ProtoReflect() protoreflect.Message {
+	mi := &file_telematics_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Module.ProtoReflect.Descriptor instead.
+func (*Module) Descriptor() ([]byte, []int) {
+	return file_telematics_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Module) GetFlags() uint32 {
+	if x != nil {
+		return x.Flags
+	}
+	return 0
+}
+
+func (x *Module) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Module) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Module) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type ModuleProperty struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Flags         uint32                 `protobuf:"varint,1,opt,name=flags,proto3" json:"flags,omitempty"`
+	ModuleId      uint32                 `protobuf:"varint,2,opt,name=module_id,json=moduleId,proto3" json:"module_id,omitempty"`
+	Id            uint32                 `protobuf:"varint,3,opt,name=id,proto3" json:"id,omitempty"`
+	Type          DataType               `protobuf:"varint,4,opt,name=type,proto3,enum=telematics.DataType" json:"type,omitempty"`
+	Min           *Value                 `protobuf:"bytes,5,opt,name=min,proto3" json:"min,omitempty"`
+	Max           *Value                 `protobuf:"bytes,6,opt,name=max,proto3" json:"max,omitempty"`
+	List          []*NameValue           `protobuf:"bytes,7,rep,name=list,proto3" json:"list,omitempty"`
+	Access        uint32                 `protobuf:"varint,8,opt,name=access,proto3" json:"access,omitempty"`
+	Name          string                 `protobuf:"bytes,9,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,10,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModuleProperty) Reset() {
+	*x = ModuleProperty{}
+	mi := &file_telematics_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModuleProperty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModuleProperty) ProtoMessage() {}
+
+func (x *ModuleProperty) ProtoReflect() protoreflect.Message {
+	mi := &file_telematics_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModuleProperty.ProtoReflect.Descriptor instead.
+func (*ModuleProperty) Descriptor() ([]byte, []int) {
+	return file_telematics_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ModuleProperty) GetFlags() uint32 {
+	if x != nil {
+		return x.Flags
+	}
+	return 0
+}
+
+func (x *ModuleProperty) GetModuleId() uint32 {
+	if x != nil {
+		return x.ModuleId
+	}
+	return 0
+}
+
+func (x *ModuleProperty) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ModuleProperty) GetType() DataType {
+	if x != nil {
+		return x.Type
+	}
+	return DataType_DATA_TYPE_NOT_SET
+}
+
+func (x *ModuleProperty) GetMin() *Value {
+	if x != nil {
+		return x.Min
+	}
+	return nil
+}
+
+func (x *ModuleProperty) GetMax() *Value {
+	if x != nil {
+		return x.Max
+	}
+	return nil
+}
+
+func (x *ModuleProperty) GetList() []*NameValue {
+	if x != nil {
+		return x.List
+	}
+	return nil
+}
+
+func (x *ModuleProperty) GetAccess() uint32 {
+	if x != nil {
+		return x.Access
+	}
+	return 0
+}
+
+func (x *ModuleProperty) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ModuleProperty) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type Command struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Flags         uint32                 `protobuf:"varint,1,opt,name=flags,proto3" json:"flags,omitempty"`
+	ModuleId      uint32                 `protobuf:"varint,2,opt,name=module_id,json=moduleId,proto3" json:"module_id,omitempty"`
+	Id            uint32                 `protobuf:"varint,3,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Command) Reset() {
+	*x = Command{}
+	mi := &file_telematics_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Command) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Command) ProtoMessage() {}
+
+func (x *Command) ProtoReflect() protoreflect.Message {
+	mi := &file_telematics_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Command.ProtoReflect.Descriptor instead.
+func (*Command) Descriptor() ([]byte, []int) {
+	return file_telematics_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Command) GetFlags() uint32 {
+	if x != nil {
+		return x.Flags
+	}
+	return 0
+}
+
+func (x *Command) GetModuleId() uint32 {
+	if x != nil {
+		return x.ModuleId
+	}
+	return 0
+}
+
+func (x *Command) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Command) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Command) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type CommandArgument struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Flags         uint32                 `protobuf:"varint,1,opt,name=flags,proto3" json:"flags,omitempty"`
+	ModuleId      uint32                 `protobuf:"varint,2,opt,name=module_id,json=moduleId,proto3" json:"module_id,omitempty"`
+	CommandId     uint32                 `protobuf:"varint,3,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`
+	Id            uint32                 `protobuf:"varint,4,opt,name=id,proto3" json:"id,omitempty"`
+	Type          DataType               `protobuf:"varint,5,opt,name=type,proto3,enum=telematics.DataType" json:"type,omitempty"`
+	Min           *Value                 `protobuf:"bytes,6,opt,name=min,proto3" json:"min,omitempty"`
+	Max           *Value                 `protobuf:"bytes,7,opt,name=max,proto3" json:"max,omitempty"`
+	List          []*NameValue           `protobuf:"bytes,8,rep,name=list,proto3" json:"list,omitempty"`
+	Required      uint32                 `protobuf:"varint,9,opt,name=required,proto3" json:"required,omitempty"`
+	Name          string                 `protobuf:"bytes,10,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,11,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommandArgument) Reset() {
+	*x = CommandArgument{}
+	mi := &file_telematics_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommandArgument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandArgument) ProtoMessage() {}
+
+func (x *CommandArgument) ProtoReflect() protoreflect.Message {
+	mi := &file_telematics_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandArgument.ProtoReflect.Descriptor instead.
+func (*CommandArgument) Descriptor() ([]byte, []int) {
+	return file_telematics_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CommandArgument) GetFlags() uint32 {
+	if x != nil {
+		return x.Flags
+	}
+	return 0
+}
+
+func (x *CommandArgument) GetModuleId() uint32 {
+	if x != nil {
+		return x.ModuleId
+	}
+	return 0
+}
+
+func (x *CommandArgument) GetCommandId() uint32 {
+	if x != nil {
+		return x.CommandId
+	}
+	return 0
+}
+
+func (x *CommandArgument) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CommandArgument) GetType() DataType {
+	if x != nil {
+		return x.Type
+	}
+	return DataType_DATA_TYPE_NOT_SET
+}
+
+func (x *CommandArgument) GetMin() *Value {
+	if x != nil {
+		return x.Min
+	}
+	return nil
+}
+
+func (x *CommandArgument) GetMax() *Value {
+	if x != nil {
+		return x.Max
+	}
+	return nil
+}
+
+func (x *CommandArgument) GetList() []*NameValue {
+	if x != nil {
+		return x.List
+	}
+	return nil
+}
+
+func (x *CommandArgument) GetRequired() uint32 {
+	if x != nil {
+		return x.Required
+	}
+	return 0
+}
+
+func (x *CommandArgument) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CommandArgument) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type ModulePropertyValue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ModuleId      uint32                 `protobuf:"varint,1,opt,name=module_id,json=moduleId,proto3" json:"module_id,omitempty"`
+	Values        []*TypedValue          `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModulePropertyValue) Reset() {
+	*x = ModulePropertyValue{}
+	mi := &file_telematics_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModulePropertyValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModulePropertyValue) ProtoMessage() {}
+
+func (x *ModulePropertyValue) ProtoReflect() protoreflect.Message {
+	mi := &file_telematics_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModulePropertyValue.ProtoReflect.Descriptor instead.
+func (*ModulePropertyValue) Descriptor() ([]byte, []int) {
+	return file_telematics_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ModulePropertyValue) GetModuleId() uint32 {
+	if x != nil {
+		return x.ModuleId
+	}
+	return 0
+}
+
+func (x *ModulePropertyValue) GetValues() []*TypedValue {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type CommandExecute struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ModuleId      uint32                 `protobuf:"varint,1,opt,name=module_id,json=moduleId,proto3" json:"module_id,omitempty"`
+	CommandId     uint32                 `protobuf:"varint,2,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`
+	Arguments     []*TypedValue          `protobuf:"bytes,3,rep,name=arguments,proto3" json:"arguments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommandExecute) Reset() {
+	*x = CommandExecute{}
+	mi := &file_telematics_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommandExecute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandExecute) ProtoMessage() {}
+
+func (x *CommandExecute) ProtoReflect() protoreflect.Message {
+	mi := &file_telematics_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandExecute.ProtoReflect.Descriptor instead.
+func (*CommandExecute) Descriptor() ([]byte, []int) {
+	return file_telematics_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CommandExecute) GetModuleId() uint32 {
+	if x != nil {
+		return x.ModuleId
+	}
+	return 0
+}
+
+func (x *CommandExecute) GetCommandId() uint32 {
+	if x != nil {
+		return x.CommandId
+	}
+	return 0
+}
+
+func (x *CommandExecute) GetArguments() []*TypedValue {
+	if x != nil {
+		return x.Arguments
+	}
+	return nil
+}
+
+type ModulePropertyDisable struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	DisabledProperties map[uint32]uint32      `protobuf:"bytes,1,rep,name=disabled_properties,json=disabledProperties,proto3" json:"disabled_properties,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ModulePropertyDisable) Reset() {
+	*x = ModulePropertyDisable{}
+	mi := &file_telematics_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModulePropertyDisable) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModulePropertyDisable) ProtoMessage() {}
+
+func (x *ModulePropertyDisable) ProtoReflect() protoreflect.Message {
+	mi := &file_telematics_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModulePropertyDisable.ProtoReflect.Descriptor instead.
+func (*ModulePropertyDisable) Descriptor() ([]byte, []int) {
+	return file_telematics_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ModulePropertyDisable) GetDisabledProperties() map[uint32]uint32 {
+	if x != nil {
+		return x.DisabledProperties
+	}
+	return nil
+}
+
+type TypedValue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          DataType               `protobuf:"varint,2,opt,name=type,proto3,enum=telematics.DataType" json:"type,omitempty"`
+	Value         *Value                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TypedValue) Reset() {
+	*x = TypedValue{}
+	mi := &file_telematics_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TypedValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TypedValue) ProtoMessage() {}
+
+func (x *TypedValue) ProtoReflect() protoreflect.Message {
+	mi := &file_telematics_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TypedValue.ProtoReflect.Descriptor instead.
+func (*TypedValue) Descriptor() ([]byte, []int) {
+	return file_telematics_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TypedValue) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TypedValue) GetType() DataType {
+	if x != nil {
+		return x.Type
+	}
+	return DataType_DATA_TYPE_NOT_SET
+}
+
+func (x *TypedValue) GetValue() *Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type NameValue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value         *Value                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NameValue) Reset() {
+	*x = NameValue{}
+	mi := &file_telematics_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NameValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NameValue) ProtoMessage() {}
+
+func (x *NameValue) ProtoReflect() protoreflect.Message {
+	mi := &file_telematics_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NameValue.ProtoReflect.Descriptor instead.
+func (*NameValue) Descriptor() ([]byte, []int) {
+	return file_telematics_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *NameValue) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NameValue) GetValue() *Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// Value holds go value of a data type, type itself is carried by owner
+type Value struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Kind:
+	//
+	//	*Value_BoolValue
+	//	*Value_IntValue
+	//	*Value_UintValue
+	//	*Value_FloatValue
+	//	*Value_DoubleValue
+	//	*Value_StringValue
+	//	*Value_BytesValue
+	//	*Value_Timestamp
+	//	*Value_Timespan
+	//	*Value_Common
+	//	*Value_IoPort
+	//	*Value_Gps
+	//	*Value_Gsm
+	//	*Value_CellInfo
+	//	*Value_Acceleration
+	//	*Value_Rgb
+	Kind          isValue_Kind `protobuf_oneof:"kind"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Value) Reset() {
+	*x = Value{}
+	mi := &file_telematics_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Value) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Value) ProtoMessage() {}
+
+func (x *Value) ProtoReflect() protoreflect.Message {
+	mi := &file_telematics_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Value.ProtoReflect.Descriptor instead.
+func (*Value) Descriptor() ([]byte, []int) {
+	return file_telematics_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Value) GetKind() isValue_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return nil
+}
+
+func (x *Value) GetBoolValue() bool {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_BoolValue); ok {
+			return x.BoolValue
+		}
+	}
+	return false
+}
+
+func (x *Value) GetIntValue() int64 {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_IntValue); ok {
+			return x.IntValue
+		}
+	}
+	return 0
+}
+
+func (x *Value) GetUintValue() uint64 {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_UintValue); ok {
+			return x.UintValue
+		}
+	}
+	return 0
+}
+
+func (x *Value) GetFloatValue() float32 {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_FloatValue); ok {
+			return x.FloatValue
+		}
+	}
+	return 0
+}
+
+func (x *Value) GetDoubleValue() float64 {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_DoubleValue); ok {
+			return x.DoubleValue
+		}
+	}
+	return 0
+}
+
+func (x *Value) GetStringValue() string {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_StringValue); ok {
+			return x.StringValue
+		}
+	}
+	return ""
+}
+
+func (x *Value) GetBytesValue() []byte {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_BytesValue); ok {
+			return x.BytesValue
+		}
+	}
+	return nil
+}
+
+func (x *Value) GetTimestamp() int64 {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_Timestamp); ok {
+			return x.Timestamp
+		}
+	}
+	return 0
+}
+
+func (x *Value) GetTimespan() int64 {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_Timespan); ok {
+			return x.Timespan
+		}
+	}
+	return 0
+}
+
+func (x *Value) GetCommon() *Common {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_Common); ok {
+			return x.Common
+		}
+	}
+	return nil
+}
+
+func (x *Value) GetIoPort() *IoPort {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_IoPort); ok {
+			return x.IoPort
+		}
+	}
+	return nil
+}
+
+func (x *Value) GetGps() *Gps {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_Gps); ok {
+			return x.Gps
+		}
+	}
+	return nil
+}
+
+func (x *Value) GetGsm() *Gsm {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_Gsm); ok {
+			return x.Gsm
+		}
+	}
+	return nil
+}
+
+func (x *Value) GetCellInfo() *CellInfo {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_CellInfo); ok {
+			return x.CellInfo
+		}
+	}
+	return nil
+}
+
+func (x *Value) GetAcceleration() *Acceleration {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_Acceleration); ok {
+			return x.Acceleration
+		}
+	}
+	return nil
+}
+
+func (x *Value) GetRgb() *Rgb {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_Rgb); ok {
+			return x.Rgb
+		}
+	}
+	return nil
+}
+
+type isValue_Kind interface {
+	isValue_Kind()
+}
+
+type Value_BoolValue struct {
+	BoolValue bool `protobuf:"varint,1,opt,name=bool_value,json=boolValue,proto3,oneof"`
+}
+
+type Value_IntValue struct {
+	IntValue int64 `protobuf:"zigzag64,2,opt,name=int_value,json=intValue,proto3,oneof"`
+}
+
+type Value_UintValue struct {
+	UintValue uint64 `protobuf:"varint,3,opt,name=uint_value,json=uintValue,proto3,oneof"`
+}
+
+type Value_FloatValue struct {
+	FloatValue float32 `protobuf:"fixed32,4,opt,name=float_value,json=floatValue,proto3,oneof"`
+}
+
+type Value_DoubleValue struct {
+	DoubleValue float64 `protobuf:"fixed64,5,opt,name=double_value,json=doubleValue,proto3,oneof"`
+}
+
+type Value_StringValue struct {
+	StringValue string `protobuf:"bytes,6,opt,name=string_value,json=stringValue,proto3,oneof"`
+}
+
+type Value_BytesValue struct {
+	BytesValue []byte `protobuf:"bytes,7,opt,name=bytes_value,json=bytesValue,proto3,oneof"`
+}
+
+type Value_Timestamp struct {
+	Timestamp int64 `protobuf:"varint,8,opt,name=timestamp,proto3,oneof"` // unix seconds
+}
+
+type Value_Timespan struct {
+	Timespan int64 `protobuf:"varint,9,opt,name=timespan,proto3,oneof"` // nanoseconds
+}
+
+type Value_Common struct {
+	Common *Common `protobuf:"bytes,10,opt,name=common,proto3,oneof"`
+}
+
+type Value_IoPort struct {
+	IoPort *IoPort `protobuf:"bytes,11,opt,name=io_port,json=ioPort,proto3,oneof"`
+}
+
+type Value_Gps struct {
+	Gps *Gps `protobuf:"bytes,12,opt,name=gps,proto3,oneof"`
+}
+
+type Value_Gsm struct {
+	Gsm *Gsm `protobuf:"bytes,13,opt,name=gsm,proto3,oneof"`
+}
+
+type Value_CellInfo struct {
+	CellInfo *CellInfo `protobuf:"bytes,14,opt,name=cell_info,json=cellInfo,proto3,oneof"`
+}
+
+type Value_Acceleration struct {
+	Acceleration *Acceleration `protobuf:"bytes,15,opt,name=acceleration,proto3,oneof"`
+}
+
+type Value_Rgb struct {
+	Rgb *Rgb `protobuf:"bytes,16,opt,name=rgb,proto3,oneof"`
+}
+
+func (*Value_BoolValue) isValue_Kind() {}
+
+func (*Value_IntValue) isValue_Kind() {}
+
+func (*Value_UintValue) isValue_Kind() {}
+
+func (*Value_FloatValue) isValue_Kind() {}
+
+func (*Value_DoubleValue) isValue_Kind() {}
+
+func (*Value_StringValue) isValue_Kind() {}
+
+func (*Value_BytesValue) isValue_Kind() {}
+
+func (*Value_Timestamp) isValue_Kind() {}
+
+func (*Value_Timespan) isValue_Kind() {}
+
+func (*Value_Common) isValue_Kind() {}
+
+func (*Value_IoPort) isValue_Kind() {}
+
+func (*Value_Gps) isValue_Kind() {}
+
+func (*Value_Gsm) isValue_Kind() {}
+
+func (*Value_CellInfo) isValue_Kind() {}
+
+func (*Value_Acceleration) isValue_Kind() {}
+
+func (*Value_Rgb) isValue_Kind() {}
+
+type Common struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Flags         uint32                 `protobuf:"varint,1,opt,name=flags,proto3" json:"flags,omitempty"`
+	State         bool                   `protobuf:"varint,2,opt,name=state,proto3" json:"state,omitempty"`
+	Percentage    uint32                 `protobuf:"varint,3,opt,name=percentage,proto3" json:"percentage,omitempty"`
+	Value         float64                `protobuf:"fixed64,4,opt,name=value,proto3" json:"value,omitempty"`
+	Meter         float64                `protobuf:"fixed64,5,opt,name=meter,proto3" json:"meter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Common) Reset() {
+	*x = Common{}
+	mi := &file_telematics_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Common) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Common) ProtoMessage() {}
+
+func (x *Common) ProtoReflect() protoreflect.Message {
+	mi := &file_telematics_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Common.ProtoReflect.Descriptor instead.
+func (*Common) Descriptor() ([]byte, []int) {
+	return file_telematics_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Common) GetFlags() uint32 {
+	if x != nil {
+		return x.Flags
+	}
+	return 0
+}
+
+func (x *Common) GetState() bool {
+	if x != nil {
+		return x.State
+	}
+	return false
+}
+
+func (x *Common) GetPercentage() uint32 {
+	if x != nil {
+		return x.Percentage
+	}
+	return 0
+}
+
+func (x *Common) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Common) GetMeter() float64 {
+	if x != nil {
+		return x.Meter
+	}
+	return 0
+}
+
+type IoPort struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Flags         uint32                 `protobuf:"varint,1,opt,name=flags,proto3" json:"flags,omitempty"` // pin directions, bit set - output
+	State         uint32                 `protobuf:"varint,2,opt,name=state,proto3" json:"state,omitempty"` // pin levels, bit set - high
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IoPort) Reset() {
+	*x = IoPort{}
+	mi := &file_telematics_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IoPort) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IoPort) ProtoMessage() {}
+
+func (x *IoPort) ProtoReflect() protoreflect.Message {
+	mi := &file_telematics_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IoPort.ProtoReflect.Descriptor instead.
+func (*IoPort) Descriptor() ([]byte, []int) {
+	return file_telematics_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *IoPort) GetFlags() uint32 {
+	if x != nil {
+		return x.Flags
+	}
+	return 0
+}
+
+func (x *IoPort) GetState() uint32 {
+	if x != nil {
+		return x.State
+	}
+	return 0
+}
+
+type Gps struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Flags         uint32                 `protobuf:"varint,1,opt,name=flags,proto3" json:"flags,omitempty"`
+	Latitude      float64                `protobuf:"fixed64,2,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,3,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Altitude      int32                  `protobuf:"zigzag32,4,opt,name=altitude,proto3" json:"altitude,omitempty"`
+	Speed         uint32                 `protobuf:"varint,5,opt,name=speed,proto3" json:"speed,omitempty"`
+	Course        uint32                 `protobuf:"varint,6,opt,name=course,proto3" json:"course,omitempty"`
+	Satellites    uint32                 `protobuf:"varint,7,opt,name=satellites,proto3" json:"satellites,omitempty"`
+	Hdop          float32                `protobuf:"fixed32,8,opt,name=hdop,proto3" json:"hdop,omitempty"`
+	Pdop          float32                `protobuf:"fixed32,9,opt,name=pdop,proto3" json:"pdop,omitempty"`
+	Fix           uint32                 `protobuf:"varint,10,opt,name=fix,proto3" json:"fix,omitempty"`
+	Valid         bool                   `protobuf:"varint,11,opt,name=valid,proto3" json:"valid,omitempty"`
+	Constellation uint32                 `protobuf:"varint,12,opt,name=constellation,proto3" json:"constellation,omitempty"`
+	Extended      uint32                 `protobuf:"varint,13,opt,name=extended,proto3" json:"extended,omitempty"`
+	PreciseSpeed  float32                `protobuf:"fixed32,14,opt,name=precise_speed,json=preciseSpeed,proto3" json:"precise_speed,omitempty"`
+	PreciseCourse float32                `protobuf:"fixed32,15,opt,name=precise_course,json=preciseCourse,proto3" json:"precise_course,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Gps) Reset() {
+	*x = Gps{}
+	mi := &file_telematics_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Gps) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Gps) ProtoMessage() {}
+
+func (x *Gps) ProtoReflect() protoreflect.Message {
+	mi := &file_telematics_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Gps.ProtoReflect.Descriptor instead.
+func (*Gps) Descriptor() ([]byte, []int) {
+	return file_telematics_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Gps) GetFlags() uint32 {
+	if x != nil {
+		return x.Flags
+	}
+	return 0
+}
+
+func (x *Gps) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Gps) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *Gps) GetAltitude() int32 {
+	if x != nil {
+		return x.Altitude
+	}
+	return 0
+}
+
+func (x *Gps) GetSpeed() uint32 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+func (x *Gps) GetCourse() uint32 {
+	if x != nil {
+		return x.Course
+	}
+	return 0
+}
+
+func (x *Gps) GetSatellites() uint32 {
+	if x != nil {
+		return x.Satellites
+	}
+	return 0
+}
+
+func (x *Gps) GetHdop() float32 {
+	if x != nil {
+		return x.Hdop
+	}
+	return 0
+}
+
+func (x *Gps) GetPdop() float32 {
+	if x != nil {
+		return x.Pdop
+	}
+	return 0
+}
+
+func (x *Gps) GetFix() uint32 {
+	if x != nil {
+		return x.Fix
+	}
+	return 0
+}
+
+func (x *Gps) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *Gps) GetConstellation() uint32 {
+	if x != nil {
+		return x.Constellation
+	}
+	return 0
+}
+
+func (x *Gps) GetExtended() uint32 {
+	if x != nil {
+		return x.Extended
+	}
+	return 0
+}
+
+func (x *Gps) GetPreciseSpeed() float32 {
+	if x != nil {
+		return x.PreciseSpeed
+	}
+	return 0
+}
+
+func (x *Gps) GetPreciseCourse() float32 {
+	if x != nil {
+		return x.PreciseCourse
+	}
+	return 0
+}
+
+type Gsm struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mcc           string                 `protobuf:"bytes,1,opt,name=mcc,proto3" json:"mcc,omitempty"`
+	Mnc           string                 `protobuf:"bytes,2,opt,name=mnc,proto3" json:"mnc,omitempty"`
+	Lac           uint32                 `protobuf:"varint,3,opt,name=lac,proto3" json:"lac,omitempty"`
+	Cid           uint32                 `protobuf:"varint,4,opt,name=cid,proto3" json:"cid,omitempty"`
+	Signal        int32                  `protobuf:"zigzag32,5,opt,name=signal,proto3" json:"signal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Gsm) Reset() {
+	*x = Gsm{}
+	mi := &file_telematics_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Gsm) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Gsm) ProtoMessage() {}
+
+func (x *Gsm) ProtoReflect() protoreflect.Message {
+	mi := &file_telematics_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Gsm.ProtoReflect.Descriptor instead.
+func (*Gsm) Descriptor() ([]byte, []int) {
+	return file_telematics_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Gsm) GetMcc() string {
+	if x != nil {
+		return x.Mcc
+	}
+	return ""
+}
+
+func (x *Gsm) GetMnc() string {
+	if x != nil {
+		return x.Mnc
+	}
+	return ""
+}
+
+func (x *Gsm) GetLac() uint32 {
+	if x != nil {
+		return x.Lac
+	}
+	return 0
+}
+
+func (x *Gsm) GetCid() uint32 {
+	if x != nil {
+		return x.Cid
+	}
+	return 0
+}
+
+func (x *Gsm) GetSignal() int32 {
+	if x != nil {
+		return x.Signal
+	}
+	return 0
+}
+
+type Cell struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Radio         uint32                 `protobuf:"varint,1,opt,name=radio,proto3" json:"radio,omitempty"`
+	Lac           uint32                 `protobuf:"varint,2,opt,name=lac,proto3" json:"lac,omitempty"`
+	Tac           uint32                 `protobuf:"varint,3,opt,name=tac,proto3" json:"tac,omitempty"`
+	Cid           uint32                 `protobuf:"varint,4,opt,name=cid,proto3" json:"cid,omitempty"`
+	Signal        int32                  `protobuf:"zigzag32,5,opt,name=signal,proto3" json:"signal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Cell) Reset() {
+	*x = Cell{}
+	mi := &file_telematics_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Cell) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cell) ProtoMessage() {}
+
+func (x *Cell) ProtoReflect() protoreflect.Message {
+	mi := &file_telematics_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cell.ProtoReflect.Descriptor instead.
+func (*Cell) Descriptor() ([]byte, []int) {
+	return file_telematics_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Cell) GetRadio() uint32 {
+	if x != nil {
+		return x.Radio
+	}
+	return 0
+}
+
+func (x *Cell) GetLac() uint32 {
+	if x != nil {
+		return x.Lac
+	}
+	return 0
+}
+
+func (x *Cell) GetTac() uint32 {
+	if x != nil {
+		return x.Tac
+	}
+	return 0
+}
+
+func (x *Cell) GetCid() uint32 {
+	if x != nil {
+		return x.Cid
+	}
+	return 0
+}
+
+func (x *Cell) GetSignal() int32 {
+	if x != nil {
+		return x.Signal
+	}
+	return 0
+}
+
+type CellInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mcc           string                 `protobuf:"bytes,1,opt,name=mcc,proto3" json:"mcc,omitempty"`
+	Mnc           string                 `protobuf:"bytes,2,opt,name=mnc,proto3" json:"mnc,omitempty"`
+	Serving       *Cell                  `protobuf:"bytes,3,opt,name=serving,proto3" json:"serving,omitempty"`
+	Neighbors     []*Cell                `protobuf:"bytes,4,rep,name=neighbors,proto3" json:"neighbors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CellInfo) Reset() {
+	*x = CellInfo{}
+	mi := &file_telematics_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CellInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CellInfo) ProtoMessage() {}
+
+func (x *CellInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_telematics_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CellInfo.ProtoReflect.Descriptor instead.
+func (*CellInfo) Descriptor() ([]byte, []int) {
+	return file_telematics_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CellInfo) GetMcc() string {
+	if x != nil {
+		return x.Mcc
+	}
+	return ""
+}
+
+func (x *CellInfo) GetMnc() string {
+	if x != nil {
+		return x.Mnc
+	}
+	return ""
+}
+
+func (x *CellInfo) GetServing() *Cell {
+	if x != nil {
+		return x.Serving
+	}
+	return nil
+}
+
+func (x *CellInfo) GetNeighbors() []*Cell {
+	if x != nil {
+		return x.Neighbors
+	}
+	return nil
+}
+
+type Acceleration struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Flags         uint32                 `protobuf:"varint,1,opt,name=flags,proto3" json:"flags,omitempty"`
+	X             float32                `protobuf:"fixed32,2,opt,name=x,proto3" json:"x,omitempty"`
+	Y             float32                `protobuf:"fixed32,3,opt,name=y,proto3" json:"y,omitempty"`
+	Z             float32                `protobuf:"fixed32,4,opt,name=z,proto3" json:"z,omitempty"`
+	Duration      uint32                 `protobuf:"varint,5,opt,name=duration,proto3" json:"duration,omitempty"`
+	GyroX         float32                `protobuf:"fixed32,6,opt,name=gyro_x,json=gyroX,proto3" json:"gyro_x,omitempty"`
+	GyroY         float32                `protobuf:"fixed32,7,opt,name=gyro_y,json=gyroY,proto3" json:"gyro_y,omitempty"`
+	GyroZ         float32                `protobuf:"fixed32,8,opt,name=gyro_z,json=gyroZ,proto3" json:"gyro_z,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Acceleration) Reset() {
+	*x = Acceleration{}
+	mi := &file_telematics_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Acceleration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Acceleration) ProtoMessage() {}
+
+func (x *Acceleration) ProtoReflect() protoreflect.Message {
+	mi := &file_telematics_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Acceleration.ProtoReflect.Descriptor instead.
+func (*Acceleration) Descriptor() ([]byte, []int) {
+	return file_telematics_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Acceleration) GetFlags() uint32 {
+	if x != nil {
+		return x.Flags
+	}
+	return 0
+}
+
+func (x *Acceleration) GetX() float32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Acceleration) GetY() float32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *Acceleration) GetZ() float32 {
+	if x != nil {
+		return x.Z
+	}
+	return 0
+}
+
+func (x *Acceleration) GetDuration() uint32 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *Acceleration) GetGyroX() float32 {
+	if x != nil {
+		return x.GyroX
+	}
+	return 0
+}
+
+func (x *Acceleration) GetGyroY() float32 {
+	if x != nil {
+		return x.GyroY
+	}
+	return 0
+}
+
+func (x *Acceleration) GetGyroZ() float32 {
+	if x != nil {
+		return x.GyroZ
+	}
+	return 0
+}
+
+type Rgb struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	R             uint32                 `protobuf:"varint,1,opt,name=r,proto3" json:"r,omitempty"`
+	G             uint32                 `protobuf:"varint,2,opt,name=g,proto3" json:"g,omitempty"`
+	B             uint32                 `protobuf:"varint,3,opt,name=b,proto3" json:"b,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Rgb) Reset() {
+	*x = Rgb{}
+	mi := &file_telematics_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rgb) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rgb) ProtoMessage() {}
+
+func (x *Rgb) ProtoReflect() protoreflect.Message {
+	mi := &file_telematics_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rgb.ProtoReflect.Descriptor instead.
+func (*Rgb) Descriptor() ([]byte, []int) {
+	return file_telematics_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Rgb) GetR() uint32 {
+	if x != nil {
+		return x.R
+	}
+	return 0
+}
+
+func (x *Rgb) GetG() uint32 {
+	if x != nil {
+		return x.G
+	}
+	return 0
+}
+
+func (x *Rgb) GetB() uint32 {
+	if x != nil {
+		return x.B
+	}
+	return 0
+}
+
+var File_telematics_proto protoreflect.FileDescriptor
+
+const file_telematics_proto_rawDesc = "" +
+	"\n" +
+	"\x10telematics.proto\x12\n" +
+	"telematics\"\x8f\x04\n" +
+	"\aRequest\x12\x1a\n" +
+	"\bsections\x18\x01 \x01(\rR\bsections\x12\x1a\n" +
+	"\bsequence\x18\x02 \x01(\rR\bsequence\x12\x1c\n" +
+	"\ttimestamp\x18\x03 \x01(\x05R\ttimestamp\x12B\n" +
+	"\x0eidentification\x18\x04 \x01(\v2\x1a.telematics.IdentificationR\x0eidentification\x12B\n" +
+	"\x0eauthentication\x18\x05 \x01(\v2\x1a.telematics.AuthenticationR\x0eauthentication\x125\n" +
+	"\tsupported\x18\x06 \x03(\x0e2\x17.telematics.SectionTypeR\tsupported\x12?\n" +
+	"\rconfiguration\x18\a \x01(\v2\x19.telematics.ConfigurationR\rconfiguration\x127\n" +
+	"\x06values\x18\b \x03(\v2\x1f.telematics.ModulePropertyValueR\x06values\x126\n" +
+	"\bexecutes\x18\t \x03(\v2\x1a.telematics.CommandExecuteR\bexecutes\x12=\n" +
+	"\bdisabled\x18\n" +
+	" \x03(\v2!.telematics.ModulePropertyDisableR\bdisabled\"d\n" +
+	"\bResponse\x12\x14\n" +
+	"\x05flags\x18\x01 \x01(\rR\x05flags\x12\x1a\n" +
+	"\bsequence\x18\x02 \x01(\rR\bsequence\x12\x10\n" +
+	"\x03crc\x18\x03 \x01(\rR\x03crc\x12\x14\n" +
+	"\x05nonce\x18\x04 \x01(\fR\x05nonce\"\xf9\x01\n" +
+	"\rConfiguration\x12\x12\n" +
+	"\x04hash\x18\x01 \x01(\rR\x04hash\x12,\n" +
+	"\amodules\x18\x02 \x03(\v2\x12.telematics.ModuleR\amodules\x12:\n" +
+	"\n" +
+	"properties\x18\x03 \x03(\v2\x1a.telematics.ModulePropertyR\n" +
+	"properties\x12/\n" +
+	"\bcommands\x18\x04 \x03(\v2\x13.telematics.CommandR\bcommands\x129\n" +
+	"\targuments\x18\x05 \x03(\v2\x1b.telematics.CommandArgumentR\targuments\"\xdc\x01\n" +
+	"\x0eIdentification\x12\x14\n" +
+	"\x05flags\x18\x01 \x01(\rR\x05flags\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\rR\x04code\x12\x1b\n" +
+	"\tcode_text\x18\x03 \x01(\tR\bcodeText\x127\n" +
+	"\vdevice_type\x18\x04 \x01(\x0e2\x16.telematics.DeviceTypeR\n" +
+	"deviceType\x12\x1a\n" +
+	"\bfirmware\x18\x05 \x01(\x05R\bfirmware\x12\x1a\n" +
+	"\bhardware\x18\x06 \x01(\x05R\bhardware\x12\x12\n" +
+	"\x04hash\x18\a \x01(\rR\x04hash\"\x8c\x01\n" +
+	"\x0eAuthentication\x12\x14\n" +
+	"\x05flags\x18\x01 \x01(\rR\x05flags\x12\x1e\n" +
+	"\n" +
+	"identifier\x18\x02 \x01(\tR\n" +
+	"identifier\x12\x16\n" +
+	"\x06secret\x18\x03 \x01(\fR\x06secret\x12\x14\n" +
+	"\x05nonce\x18\x04 \x01(\fR\x05nonce\x12\x16\n" +
+	"\x06digest\x18\x05 \x01(\fR\x06digest\"d\n" +
+	"\x06Module\x12\x14\n" +
+	"\x05flags\x18\x01 \x01(\rR\x05flags\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\rR\x02id\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\"\xc0\x02\n" +
+	"\x0eModuleProperty\x12\x14\n" +
+	"\x05flags\x18\x01 \x01(\rR\x05flags\x12\x1b\n" +
+	"\tmodule_id\x18\x02 \x01(\rR\bmoduleId\x12\x0e\n" +
+	"\x02id\x18\x03 \x01(\rR\x02id\x12(\n" +
+	"\x04type\x18\x04 \x01(\x0e2\x14.telematics.DataTypeR\x04type\x12#\n" +
+	"\x03min\x18\x05 \x01(\v2\x11.telematics.ValueR\x03min\x12#\n" +
+	"\x03max\x18\x06 \x01(\v2\x11.telematics.ValueR\x03max\x12)\n" +
+	"\x04list\x18\a \x03(\v2\x15.telematics.NameValueR\x04list\x12\x16\n" +
+	"\x06access\x18\b \x01(\rR\x06access\x12\x12\n" +
+	"\x04name\x18\t \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\n" +
+	" \x01(\tR\vdescription\"\x82\x01\n" +
+	"\aCommand\x12\x14\n" +
+	"\x05flags\x18\x01 \x01(\rR\x05flags\x12\x1b\n" +
+	"\tmodule_id\x18\x02 \x01(\rR\bmoduleId\x12\x0e\n" +
+	"\x02id\x18\x03 \x01(\rR\x02id\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\"\xe4\x02\n" +
+	"\x0fCommandArgument\x12\x14\n" +
+	"\x05flags\x18\x01 \x01(\rR\x05flags\x12\x1b\n" +
+	"\tmodule_id\x18\x02 \x01(\rR\bmoduleId\x12\x1d\n" +
+	"\n" +
+	"command_id\x18\x03 \x01(\rR\tcommandId\x12\x0e\n" +
+	"\x02id\x18\x04 \x01(\rR\x02id\x12(\n" +
+	"\x04type\x18\x05 \x01(\x0e2\x14.telematics.DataTypeR\x04type\x12#\n" +
+	"\x03min\x18\x06 \x01(\v2\x11.telematics.ValueR\x03min\x12#\n" +
+	"\x03max\x18\a \x01(\v2\x11.telematics.ValueR\x03max\x12)\n" +
+	"\x04list\x18\b \x03(\v2\x15.telematics.NameValueR\x04list\x12\x1a\n" +
+	"\brequired\x18\t \x01(\rR\brequired\x12\x12\n" +
+	"\x04name\x18\n" +
+	" \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\v \x01(\tR\vdescription\"b\n" +
+	"\x13ModulePropertyValue\x12\x1b\n" +
+	"\tmodule_id\x18\x01 \x01(\rR\bmoduleId\x12.\n" +
+	"\x06values\x18\x02 \x03(\v2\x16.telematics.TypedValueR\x06values\"\x82\x01\n" +
+	"\x0eCommandExecute\x12\x1b\n" +
+	"\tmodule_id\x18\x01 \x01(\rR\bmoduleId\x12\x1d\n" +
+	"\n" +
+	"command_id\x18\x02 \x01(\rR\tcommandId\x124\n" +
+	"\targuments\x18\x03 \x03(\v2\x16.telematics.TypedValueR\targuments\"\xca\x01\n" +
+	"\x15ModulePropertyDisable\x12j\n" +
+	"\x13disabled_properties\x18\x01 \x03(\v29.telematics.ModulePropertyDisable.DisabledPropertiesEntryR\x12disabledProperties\x1aE\n" +
+	"\x17DisabledPropertiesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\rR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\rR\x05value:\x028\x01\"o\n" +
+	"\n" +
+	"TypedValue\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12(\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x14.telematics.DataTypeR\x04type\x12'\n" +
+	"\x05value\x18\x03 \x01(\v2\x11.telematics.ValueR\x05value\"H\n" +
+	"\tNameValue\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12'\n" +
+	"\x05value\x18\x02 \x01(\v2\x11.telematics.ValueR\x05value\"\xff\x04\n" +
+	"\x05Value\x12\x1f\n" +
+	"\n" +
+	"bool_value\x18\x01 \x01(\bH\x00R\tboolValue\x12\x1d\n" +
+	"\tint_value\x18\x02 \x01(\x12H\x00R\bintValue\x12\x1f\n" +
+	"\n" +
+	"uint_value\x18\x03 \x01(\x04H\x00R\tuintValue\x12!\n" +
+	"\vfloat_value\x18\x04 \x01(\x02H\x00R\n" +
+	"floatValue\x12#\n" +
+	"\fdouble_value\x18\x05 \x01(\x01H\x00R\vdoubleValue\x12#\n" +
+	"\fstring_value\x18\x06 \x01(\tH\x00R\vstringValue\x12!\n" +
+	"\vbytes_value\x18\a \x01(\fH\x00R\n" +
+	"bytesValue\x12\x1e\n" +
+	"\ttimestamp\x18\b \x01(\x03H\x00R\ttimestamp\x12\x1c\n" +
+	"\btimespan\x18\t \x01(\x03H\x00R\btimespan\x12,\n" +
+	"\x06common\x18\n" +
+	" \x01(\v2\x12.telematics.CommonH\x00R\x06common\x12-\n" +
+	"\aio_port\x18\v \x01(\v2\x12.telematics.IoPortH\x00R\x06ioPort\x12#\n" +
+	"\x03gps\x18\f \x01(\v2\x0f.telematics.GpsH\x00R\x03gps\x12#\n" +
+	"\x03gsm\x18\r \x01(\v2\x0f.telematics.GsmH\x00R\x03gsm\x123\n" +
+	"\tcell_info\x18\x0e \x01(\v2\x14.telematics.CellInfoH\x00R\bcellInfo\x12>\n" +
+	"\facceleration\x18\x0f \x01(\v2\x18.telematics.AccelerationH\x00R\facceleration\x12#\n" +
+	"\x03rgb\x18\x10 \x01(\v2\x0f.telematics.RgbH\x00R\x03rgbB\x06\n" +
+	"\x04kind\"\x80\x01\n" +
+	"\x06Common\x12\x14\n" +
+	"\x05flags\x18\x01 \x01(\rR\x05flags\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\bR\x05state\x12\x1e\n" +
+	"\n" +
+	"percentage\x18\x03 \x01(\rR\n" +
+	"percentage\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\x01R\x05value\x12\x14\n" +
+	"\x05meter\x18\x05 \x01(\x01R\x05meter\"4\n" +
+	"\x06IoPort\x12\x14\n" +
+	"\x05flags\x18\x01 \x01(\rR\x05flags\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\rR\x05state\"\x9d\x03\n" +
+	"\x03Gps\x12\x14\n" +
+	"\x05flags\x18\x01 \x01(\rR\x05flags\x12\x1a\n" +
+	"\blatitude\x18\x02 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x03 \x01(\x01R\tlongitude\x12\x1a\n" +
+	"\baltitude\x18\x04 \x01(\x11R\baltitude\x12\x14\n" +
+	"\x05speed\x18\x05 \x01(\rR\x05speed\x12\x16\n" +
+	"\x06course\x18\x06 \x01(\rR\x06course\x12\x1e\n" +
+	"\n" +
+	"satellites\x18\a \x01(\rR\n" +
+	"satellites\x12\x12\n" +
+	"\x04hdop\x18\b \x01(\x02R\x04hdop\x12\x12\n" +
+	"\x04pdop\x18\t \x01(\x02R\x04pdop\x12\x10\n" +
+	"\x03fix\x18\n" +
+	" \x01(\rR\x03fix\x12\x14\n" +
+	"\x05valid\x18\v \x01(\bR\x05valid\x12$\n" +
+	"\rconstellation\x18\f \x01(\rR\rconstellation\x12\x1a\n" +
+	"\bextended\x18\r \x01(\rR\bextended\x12#\n" +
+	"\rprecise_speed\x18\x0e \x01(\x02R\fpreciseSpeed\x12%\n" +
+	"\x0eprecise_course\x18\x0f \x01(\x02R\rpreciseCourse\"e\n" +
+	"\x03Gsm\x12\x10\n" +
+	"\x03mcc\x18\x01 \x01(\tR\x03mcc\x12\x10\n" +
+	"\x03mnc\x18\x02 \x01(\tR\x03mnc\x12\x10\n" +
+	"\x03lac\x18\x03 \x01(\rR\x03lac\x12\x10\n" +
+	"\x03cid\x18\x04 \x01(\rR\x03cid\x12\x16\n" +
+	"\x06signal\x18\x05 \x01(\x11R\x06signal\"j\n" +
+	"\x04Cell\x12\x14\n" +
+	"\x05radio\x18\x01 \x01(\rR\x05radio\x12\x10\n" +
+	"\x03lac\x18\x02 \x01(\rR\x03lac\x12\x10\n" +
+	"\x03tac\x18\x03 \x01(\rR\x03tac\x12\x10\n" +
+	"\x03cid\x18\x04 \x01(\rR\x03cid\x12\x16\n" +
+	"\x06signal\x18\x05 \x01(\x11R\x06signal\"\x8a\x01\n" +
+	"\bCellInfo\x12\x10\n" +
+	"\x03mcc\x18\x01 \x01(\tR\x03mcc\x12\x10\n" +
+	"\x03mnc\x18\x02 \x01(\tR\x03mnc\x12*\n" +
+	"\aserving\x18\x03 \x01(\v2\x10.telematics.CellR\aserving\x12.\n" +
+	"\tneighbors\x18\x04 \x03(\v2\x10.telematics.CellR\tneighbors\"\xaf\x01\n" +
+	"\fAcceleration\x12\x14\n" +
+	"\x05flags\x18\x01 \x01(\rR\x05flags\x12\f\n" +
+	"\x01x\x18\x02 \x01(\x02R\x01x\x12\f\n" +
+	"\x01y\x18\x03 \x01(\x02R\x01y\x12\f\n" +
+	"\x01z\x18\x04 \x01(\x02R\x01z\x12\x1a\n" +
+	"\bduration\x18\x05 \x01(\rR\bduration\x12\x15\n" +
+	"\x06gyro_x\x18\x06 \x01(\x02R\x05gyroX\x12\x15\n" +
+	"\x06gyro_y\x18\a \x01(\x02R\x05gyroY\x12\x15\n" +
+	"\x06gyro_z\x18\b \x01(\x02R\x05gyroZ\"/\n" +
+	"\x03Rgb\x12\f\n" +
+	"\x01r\x18\x01 \x01(\rR\x01r\x12\f\n" +
+	"\x01g\x18\x02 \x01(\rR\x01g\x12\f\n" +
+	"\x01b\x18\x03 \x01(\rR\x01b*\xbb\x02\n" +
+	"\vSectionType\x12\x13\n" +
+	"\x0fSECTION_UNKNOWN\x10\x00\x12\x1a\n" +
+	"\x16SECTION_IDENTIFICATION\x10\x01\x12\x1a\n" +
+	"\x16SECTION_AUTHENTICATION\x10\x02\x12\x12\n" +
+	"\x0eSECTION_MODULE\x10\x03\x12\x1b\n" +
+	"\x17SECTION_MODULE_PROPERTY\x10\x04\x12!\n" +
+	"\x1dSECTION_MODULE_PROPERTY_VALUE\x10\x05\x12$\n" +
+	" SECTION_MODULE_PROPERTY_DISABLED\x10\x06\x12\x13\n" +
+	"\x0fSECTION_COMMAND\x10\a\x12\x1c\n" +
+	"\x18SECTION_COMMAND_ARGUMENT\x10\b\x12\x1b\n" +
+	"\x17SECTION_COMMAND_EXECUTE\x10\t\x12\x15\n" +
+	"\x11SECTION_SUPPORTED\x10\n" +
+	"*\xe4\x01\n" +
+	"\n" +
+	"DeviceType\x12\x1d\n" +
+	"\x19DEVICE_TYPE_NOT_SPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17DEVICE_TYPE_APPLICATION\x10\x01\x12\x18\n" +
+	"\x14DEVICE_TYPE_PERSONAL\x10\x02\x12\x1a\n" +
+	"\x16DEVICE_TYPE_STATIONARY\x10\x03\x12\x13\n" +
+	"\x0fDEVICE_TYPE_CAR\x10\x04\x12\x17\n" +
+	"\x13DEVICE_TYPE_CAR_OBD\x10\x05\x12\x1a\n" +
+	"\x16DEVICE_TYPE_CAR_SOCKET\x10\x06\x12\x1a\n" +
+	"\x16DEVICE_TYPE_CAR_BEACON\x10\a*\xcf\v\n" +
+	"\bDataType\x12\x15\n" +
+	"\x11DATA_TYPE_NOT_SET\x10\x00\x12\x12\n" +
+	"\x0eDATA_TYPE_BOOL\x10\x01\x12\x13\n" +
+	"\x0fDATA_TYPE_SBYTE\x10\x02\x12\x12\n" +
+	"\x0eDATA_TYPE_BYTE\x10\x03\x12\x13\n" +
+	"\x0fDATA_TYPE_SHORT\x10\x04\x12\x14\n" +
+	"\x10DATA_TYPE_USHORT\x10\x05\x12\x13\n" +
+	"\x0fDATA_TYPE_INT24\x10\x06\x12\x14\n" +
+	"\x10DATA_TYPE_UINT24\x10\a\x12\x11\n" +
+	"\rDATA_TYPE_INT\x10\b\x12\x12\n" +
+	"\x0eDATA_TYPE_UINT\x10\t\x12\x12\n" +
+	"\x0eDATA_TYPE_LONG\x10\n" +
+	"\x12\x13\n" +
+	"\x0fDATA_TYPE_ULONG\x10\v\x12\x13\n" +
+	"\x0fDATA_TYPE_FLOAT\x10\f\x12\x14\n" +
+	"\x10DATA_TYPE_DOUBLE\x10\r\x12\x13\n" +
+	"\x0fDATA_TYPE_ARRAY\x10\x0e\x12\x14\n" +
+	"\x10DATA_TYPE_STRING\x10\x0f\x12\x14\n" +
+	"\x10DATA_TYPE_BINARY\x10\x10\x12\x10\n" +
+	"\fDATA_TYPE_ID\x10\x11\x12\x12\n" +
+	"\x0eDATA_TYPE_NAME\x10\x12\x12\x14\n" +
+	"\x10DATA_TYPE_COMMON\x10\x13\x12\x18\n" +
+	"\x14DATA_TYPE_OPEN_CLOSE\x10\x14\x12\x14\n" +
+	"\x10DATA_TYPE_ON_OFF\x10\x15\x12\x14\n" +
+	"\x10DATA_TYPE_YES_NO\x10\x16\x12\x14\n" +
+	"\x10DATA_TYPE_IO_PIN\x10\x17\x12\x14\n" +
+	"\x10DATA_TYPE_TAMPER\x10\x18\x12\x13\n" +
+	"\x0fDATA_TYPE_BREAK\x10\x19\x12\x16\n" +
+	"\x12DATA_TYPE_IGNITION\x10\x1a\x12\x16\n" +
+	"\x12DATA_TYPE_MOVEMENT\x10\x1b\x12\x13\n" +
+	"\x0fDATA_TYPE_ALARM\x10\x1c\x12\x13\n" +
+	"\x0fDATA_TYPE_PANIC\x10\x1d\x12\x13\n" +
+	"\x0fDATA_TYPE_SMOKE\x10\x1e\x12\x17\n" +
+	"\x13DATA_TYPE_FREQUENCY\x10\x1f\x12\x14\n" +
+	"\x10DATA_TYPE_ANALOG\x10 \x12\x17\n" +
+	"\x13DATA_TYPE_TIMESTAMP\x10!\x12\x16\n" +
+	"\x12DATA_TYPE_TIMESPAN\x10\"\x12\x19\n" +
+	"\x15DATA_TYPE_TEMPERATURE\x10#\x12\x16\n" +
+	"\x12DATA_TYPE_HUMIDITY\x10$\x12\x16\n" +
+	"\x12DATA_TYPE_PRESSURE\x10%\x12\x14\n" +
+	"\x10DATA_TYPE_WEIGHT\x10&\x12\x16\n" +
+	"\x12DATA_TYPE_LOUDNESS\x10'\x12\x13\n" +
+	"\x0fDATA_TYPE_ANGLE\x10(\x12\x13\n" +
+	"\x0fDATA_TYPE_SPEED\x10)\x12\x15\n" +
+	"\x11DATA_TYPE_MILEAGE\x10*\x12\x11\n" +
+	"\rDATA_TYPE_RPM\x10+\x12\x1a\n" +
+	"\x16DATA_TYPE_ENGINE_HOURS\x10,\x12\x16\n" +
+	"\x12DATA_TYPE_DISTANCE\x10-\x12\x16\n" +
+	"\x12DATA_TYPE_IDENTIFY\x10.\x12\x15\n" +
+	"\x11DATA_TYPE_VOLTAGE\x10/\x12\x15\n" +
+	"\x11DATA_TYPE_BATTERY\x100\x12\x13\n" +
+	"\x0fDATA_TYPE_POWER\x101\x12\x14\n" +
+	"\x10DATA_TYPE_LIQUID\x102\x12\x13\n" +
+	"\x0fDATA_TYPE_WATER\x103\x12\x12\n" +
+	"\x0eDATA_TYPE_FUEL\x104\x12\x11\n" +
+	"\rDATA_TYPE_GAS\x105\x12\x15\n" +
+	"\x11DATA_TYPE_IO_PORT\x106\x12\x11\n" +
+	"\rDATA_TYPE_GPS\x107\x12\x11\n" +
+	"\rDATA_TYPE_GSM\x108\x12\x1a\n" +
+	"\x16DATA_TYPE_ACCELERATION\x109\x12\x1b\n" +
+	"\x17DATA_TYPE_DATA_SAMPLING\x10:\x12\x13\n" +
+	"\x0fDATA_TYPE_SOUND\x10;\x12\x16\n" +
+	"\x12DATA_TYPE_ACCIDENT\x10<\x12\x1a\n" +
+	"\x16DATA_TYPE_TEXT_MESSAGE\x10=\x12\x19\n" +
+	"\x15DATA_TYPE_ILLUMINANCE\x10>\x12\x17\n" +
+	"\x13DATA_TYPE_RADIATION\x10?\x12\x11\n" +
+	"\rDATA_TYPE_RGB\x10A\x12\x17\n" +
+	"\x13DATA_TYPE_CELL_INFO\x10BB-Z+github.com/boiledgas/protocol/telematics/pbb\x06proto3"
+
+var (
+	file_telematics_proto_rawDescOnce sync.Once
+	file_telematics_proto_rawDescData []byte
+)
+
+func file_telematics_proto_rawDescGZIP() []byte {
+	file_telematics_proto_rawDescOnce.Do(func() {
+		file_telematics_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_telematics_proto_rawDesc), len(file_telematics_proto_rawDesc)))
+	})
+	return file_telematics_proto_rawDescData
+}
+
+var file_telematics_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_telematics_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_telematics_proto_goTypes = []any{
+	(SectionType)(0),              // 0: telematics.SectionType
+	(DeviceType)(0),               // 1: telematics.DeviceType
+	(DataType)(0),                 // 2: telematics.DataType
+	(*Request)(nil),               // 3: telematics.Request
+	(*Response)(nil),              // 4: telematics.Response
+	(*Configuration)(nil),         // 5: telematics.Configuration
+	(*Identification)(nil),        // 6: telematics.Identification
+	(*Authentication)(nil),        // 7: telematics.Authentication
+	(*Module)(nil),                // 8: telematics.Module
+	(*ModuleProperty)(nil),        // 9: telematics.ModuleProperty
+	(*Command)(nil),               // 10: telematics.Command
+	(*CommandArgument)(nil),       // 11: telematics.CommandArgument
+	(*ModulePropertyValue)(nil),   // 12: telematics.ModulePropertyValue
+	(*CommandExecute)(nil),        // 13: telematics.CommandExecute
+	(*ModulePropertyDisable)(nil), // 14: telematics.ModulePropertyDisable
+	(*TypedValue)(nil),            // 15: telematics.TypedValue
+	(*NameValue)(nil),             // 16: telematics.NameValue
+	(*Value)(nil),                 // 17: telematics.Value
+	(*Common)(nil),                // 18: telematics.Common
+	(*IoPort)(nil),                // 19: telematics.IoPort
+	(*Gps)(nil),                   // 20: telematics.Gps
+	(*Gsm)(nil),                   // 21: telematics.Gsm
+	(*Cell)(nil),                  // 22: telematics.Cell
+	(*CellInfo)(nil),              // 23: telematics.CellInfo
+	(*Acceleration)(nil),          // 24: telematics.Acceleration
+	(*Rgb)(nil),                   // 25: telematics.Rgb
+	nil,                           // 26: telematics.ModulePropertyDisable.DisabledPropertiesEntry
+}
+var file_telematics_proto_depIdxs = []int32{
+	6,  // 0: telematics.Request.identification:type_name -> telematics.Identification
+	7,  // 1: telematics.Request.authentication:type_name -> telematics.Authentication
+	0,  // 2: telematics.Request.supported:type_name -> telematics.SectionType
+	5,  // 3: telematics.Request.configuration:type_name -> telematics.Configuration
+	12, // 4: telematics.Request.values:type_name -> telematics.ModulePropertyValue
+	13, // 5: telematics.Request.executes:type_name -> telematics.CommandExecute
+	14, // 6: telematics.Request.disabled:type_name -> telematics.ModulePropertyDisable
+	8,  // 7: telematics.Configuration.modules:type_name -> telematics.Module
+	9,  // 8: telematics.Configuration.properties:type_name -> telematics.ModuleProperty
+	10, // 9: telematics.Configuration.commands:type_name -> telematics.Command
+	11, // 10: telematics.Configuration.arguments:type_name -> telematics.CommandArgument
+	1,  // 11: telematics.Identification.device_type:type_name -> telematics.DeviceType
+	2,  // 12: telematics.ModuleProperty.type:type_name -> telematics.DataType
+	17, // 13: telematics.ModuleProperty.min:type_name -> telematics.Value
+	17, // 14: telematics.ModuleProperty.max:type_name -> telematics.Value
+	16, // 15: telematics.ModuleProperty.list:type_name -> telematics.NameValue
+	2,  // 16: telematics.CommandArgument.type:type_name -> telematics.DataType
+	17, // 17: telematics.CommandArgument.min:type_name -> telematics.Value
+	17, // 18: telematics.CommandArgument.max:type_name -> telematics.Value
+	16, // 19: telematics.CommandArgument.list:type_name -> telematics.NameValue
+	15, // 20: telematics.ModulePropertyValue.values:type_name -> telematics.TypedValue
+	15, // 21: telematics.CommandExecute.arguments:type_name -> telematics.TypedValue
+	26, // 22: telematics.ModulePropertyDisable.disabled_properties:type_name -> telematics.ModulePropertyDisable.DisabledPropertiesEntry
+	2,  // 23: telematics.TypedValue.type:type_name -> telematics.DataType
+	17, // 24: telematics.TypedValue.value:type_name -> telematics.Value
+	17, // 25: telematics.NameValue.value:type_name -> telematics.Value
+	18, // 26: telematics.Value.common:type_name -> telematics.Common
+	19, // 27: telematics.Value.io_port:type_name -> telematics.IoPort
+	20, // 28: telematics.Value.gps:type_name -> telematics.Gps
+	21, // 29: telematics.Value.gsm:type_name -> telematics.Gsm
+	23, // 30: telematics.Value.cell_info:type_name -> telematics.CellInfo
+	24, // 31: telematics.Value.acceleration:type_name -> telematics.Acceleration
+	25, // 32: telematics.Value.rgb:type_name -> telematics.Rgb
+	22, // 33: telematics.CellInfo.serving:type_name -> telematics.Cell
+	22, // 34: telematics.CellInfo.neighbors:type_name -> telematics.Cell
+	35, // [35:35] is the sub-list for method output_type
+	35, // [35:35] is the sub-list for method input_type
+	35, // [35:35] is the sub-list for extension type_name
+	35, // [35:35] is the sub-list for extension extendee
+	0,  // [0:35] is the sub-list for field type_name
+}
+
+func init() { file_telematics_proto_init() }
+func file_telematics_proto_init() {
+	if File_telematics_proto != nil {
+		return
+	}
+	file_telematics_proto_msgTypes[14].OneofWrappers = []any{
+		(*Value_BoolValue)(nil),
+		(*Value_IntValue)(nil),
+		(*Value_UintValue)(nil),
+		(*Value_FloatValue)(nil),
+		(*Value_DoubleValue)(nil),
+		(*Value_StringValue)(nil),
+		(*Value_BytesValue)(nil),
+		(*Value_Timestamp)(nil),
+		(*Value_Timespan)(nil),
+		(*Value_Common)(nil),
+		(*Value_IoPort)(nil),
+		(*Value_Gps)(nil),
+		(*Value_Gsm)(nil),
+		(*Value_CellInfo)(nil),
+		(*Value_Acceleration)(nil),
+		(*Value_Rgb)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_telematics_proto_rawDesc), len(file_telematics_proto_rawDesc)),
+			NumEnums:      3,
+			NumMessages:   24,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_telematics_proto_goTypes,
+		DependencyIndexes: file_telematics_proto_depIdxs,
+		EnumInfos:         file_telematics_proto_enumTypes,
+		MessageInfos:      file_telematics_proto_msgTypes,
+	}.Build()
+	File_telematics_proto = out.File
+	file_telematics_proto_goTypes = nil
+	file_telematics_proto_depIdxs = nil
+}
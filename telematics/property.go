@@ -0,0 +1,104 @@
+package telematics
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/boiledgas/protocol/telematics/section"
+	"github.com/boiledgas/protocol/telematics/value"
+)
+
+var (
+	ErrPropertyUnknown  = errors.New("property not defined")
+	ErrPropertyReadOnly = errors.New("property not writable")
+	ErrRejected         = errors.New("request rejected by device")
+)
+
+// PropertyWrite builds property values sent to device, properties are resolved by name from configuration
+type PropertyWrite struct {
+	builder
+}
+
+func NewPropertyWrite(conf *Configuration, module string) *PropertyWrite {
+	return &PropertyWrite{builder: newBuilder(conf, module)}
+}
+
+func (p *PropertyWrite) Value(name string, v interface{}) *PropertyWrite {
+	p.Values[name] = v
+	return p
+}
+
+// Section checks access and limits of properties and returns property value section,
+// property errors are returned as *BuildError
+func (p *PropertyWrite) Section() (s section.ModulePropertyValue, err error) {
+	target := fmt.Sprintf("module %v", p.Module)
+	module, err := p.module(target)
+	if err != nil {
+		return
+	}
+
+	s = section.ModulePropertyValue{
+		ModuleId: module.Id,
+		Values:   make(map[byte]interface{}),
+		Types:    make(map[byte]value.DataType),
+	}
+	buildErr := BuildError{Target: target}
+	for _, name := range p.names() {
+		property, ok := p.Configuration.PropertyByName(module.Id, name)
+		if !ok {
+			buildErr.add("property", name, 0, ErrPropertyUnknown)
+			continue
+		}
+		if !property.IsWritable() {
+			buildErr.add("property", name, property.Id, ErrPropertyReadOnly)
+			continue
+		}
+		v, err := property.Check(p.Values[name])
+		if err != nil {
+			buildErr.add("property", name, property.Id, err)
+			continue
+		}
+		s.Values[property.Id] = v
+		s.Types[property.Id] = property.Type
+	}
+	err = buildErr.err()
+	return
+}
+
+// Request returns request with single property value section
+func (p *PropertyWrite) Request() (r Request, err error) {
+	var s section.ModulePropertyValue
+	if s, err = p.Section(); err != nil {
+		return
+	}
+	r.Sequence = Sequence()
+	r.Values = []section.ModulePropertyValue{s}
+	r.Set(section.FLAG_MODULE_PROPERTY_VALUE, true)
+	return
+}
+
+// Send writes property values by client exchange and waits for device acknowledgement
+func (p *PropertyWrite) Send(e *Exchange) error {
+	return p.send(e.Send)
+}
+
+// SendSession writes property values to device of server session and waits for acknowledgement
+func (p *PropertyWrite) SendSession(s *Session, timeout time.Duration) error {
+	return p.send(func(r *Request) (Response, error) { return s.Exchange(r, timeout) })
+}
+
+func (p *PropertyWrite) send(exchange func(r *Request) (Response, error)) error {
+	r, err := p.Request()
+	if err != nil {
+		return err
+	}
+	resp, err := exchange(&r)
+	if err != nil {
+		return err
+	}
+	if resp.Has(RESPONSE_ERROR) {
+		return fmt.Errorf("module %v: %w", p.Module, ErrRejected)
+	}
+	return nil
+}
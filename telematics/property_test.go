@@ -0,0 +1,158 @@
+package telematics
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/boiledgas/protocol/telematics/section"
+)
+
+func Test_PropertyWriteErrors(t *testing.T) {
+	conf := testConfiguration()
+	_, err := NewPropertyWrite(&conf, "tracker").Value("interval", time.Second).Value("speed", float32(1)).Value("mode", 1).Section()
+	writeErr, ok := err.(*BuildError)
+	if !ok || len(writeErr.Fields) != 3 {
+		t.Fatalf("property write error wrong: %v", err)
+	}
+	if !errors.Is(writeErr.Fields[0], section.ErrBelowMin) || writeErr.Fields[0].Kind != "property" {
+		t.Errorf("min error wrong: %v", writeErr.Fields[0])
+	}
+	if !errors.Is(writeErr.Fields[1], ErrPropertyUnknown) {
+		t.Errorf("unknown error wrong: %v", writeErr.Fields[1])
+	}
+	if !errors.Is(writeErr.Fields[2], ErrPropertyReadOnly) {
+		t.Errorf("access error wrong: %v", writeErr.Fields[2])
+	}
+}
+
+func Test_PropertyWriteSend(t *testing.T) {
+	conf := testConfiguration()
+	server, device := net.Pipe()
+	defer server.Close()
+	defer device.Close()
+
+	received := make(chan Request, 1)
+	go func() {
+		r := NewReader(device)
+		r.Configuration = &conf
+		req := Request{}
+		if err := r.ReadRequest(&req); err != nil {
+			close(received)
+			return
+		}
+		received <- req
+		w := NewWriter(device)
+		// unrelated packet before acknowledgement
+		w.WriteResponse(&Response{Sequence: req.Sequence + 1})
+		w.WriteResponse(&Response{Sequence: req.Sequence})
+	}()
+
+	handled := 0
+	e := Exchange{Conn: server, Configuration: &conf, Timeout: time.Second, Handler: func(p *Packet) { handled++ }}
+	if err := NewPropertyWrite(&conf, "tracker").Value("interval", time.Minute).Send(&e); err != nil {
+		t.Fatal(err)
+	}
+	req := <-received
+	if req.Values[0].ModuleId != 3 || req.Values[0].Values[1] != time.Minute {
+		t.Errorf("request wrong: %v", req)
+	}
+	if handled != 1 {
+		t.Errorf("handled packets wrong: %v", handled)
+	}
+}
+
+func Test_ExchangeTimeout(t *testing.T) {
+	conf := testConfiguration()
+	server, device := net.Pipe()
+	defer server.Close()
+	defer device.Close()
+	go func() {
+		buf := make([]byte, 64)
+		for {
+			if _, err := device.Read(buf); err != nil {
+				return
+			}
+		}
+	}()
+	e := Exchange{Conn: server, Configuration: &conf, Timeout: 50 * time.Millisecond}
+	if _, err := e.Send(&Request{Sequence: 1}); err != ErrResponseTimeout {
+		t.Errorf("timeout error wrong: %v", err)
+	}
+	if _, err := e.Send(&Request{Sequence: 2}); err != ErrResponseTimeout {
+		t.Errorf("exchange not usable after timeout: %v", err)
+	}
+}
+
+func Test_ExchangeBrokenPacket(t *testing.T) {
+	conf := testConfiguration()
+	server, device := net.Pipe()
+	defer server.Close()
+	defer device.Close()
+	go func() {
+		buf := make([]byte, 64)
+		device.Read(buf)
+		device.Write([]byte{PACKET_TYPE_RESPONSE}) // response cut off
+	}()
+	e := Exchange{Conn: server, Configuration: &conf, Timeout: 50 * time.Millisecond}
+	if _, err := e.Send(&Request{Sequence: 1}); err == nil {
+		t.Error("partial response read")
+	}
+	if _, err := e.Send(&Request{Sequence: 2}); err != ErrStreamBroken {
+		t.Errorf("exchange used after partial packet: %v", err)
+	}
+}
+
+func Test_PropertyWriteSession(t *testing.T) {
+	conf := testConfiguration()
+	srv := Server{}
+	device, conn := net.Pipe()
+	done := make(chan error)
+	go func() { done <- srv.ServeConn(conn) }()
+
+	w := NewWriter(device)
+	w.Configuration = &conf
+	reader := NewReader(device)
+	reader.Configuration = &conf
+	hello := Request{Sequence: Sequence(), Conf: conf}
+	hello.Set(section.FLAG_MODULE, true)
+	hello.Set(section.FLAG_MODULE_PROPERTY, true)
+	if err := w.WriteRequest(&hello); err != nil {
+		t.Fatal(err)
+	}
+	p := Packet{}
+	if err := reader.Read(&p); err != nil {
+		t.Fatal(err)
+	}
+	s := srv.Sessions()[0]
+
+	received := make(chan Request, 1)
+	go func() {
+		p := Packet{}
+		if err := reader.Read(&p); err != nil || !p.Has(FLAG_REQUEST) {
+			close(received)
+			return
+		}
+		received <- p.Request
+		w.WriteResponse(&Response{Sequence: p.Request.Sequence + 1})
+		w.WriteResponse(&Response{Sequence: p.Request.Sequence, Flags: RESPONSE_ERROR})
+	}()
+	err := NewPropertyWrite(&conf, "tracker").Value("interval", time.Minute).SendSession(s, time.Second)
+	if !errors.Is(err, ErrRejected) {
+		t.Errorf("rejection not returned: %v", err)
+	}
+	if req := <-received; req.Values[0].Values[1] != time.Minute {
+		t.Errorf("request wrong: %v", req)
+	}
+
+	go func() {
+		p := Packet{}
+		reader.Read(&p)
+		device.Close()
+	}()
+	if err = NewPropertyWrite(&conf, "tracker").Value("interval", time.Minute).SendSession(s, 0); err != ErrSessionClosed {
+		t.Errorf("closed session error wrong: %v", err)
+	}
+	<-done
+}
@@ -10,15 +10,19 @@ import (
 
 type TelematicsReader struct {
 	Configuration *Configuration
+	Validate      bool    // check values against configuration, violations are returned as *ValidationError
+	Metrics       Metrics // receives counters of packets read, nil disables instrumentation
 	checksum      utils.Checksum
 	reader        io.Reader
+	counter       *byteCounter
 	buffer        [255]byte // skip buffer
 }
 
 func NewReader(r io.Reader) *TelematicsReader {
-	checksum := utils.Checksum{Table: utils.CRC8[:]}
-	reader := TelematicsReader{checksum: checksum, reader: io.TeeReader(r, &checksum)}
-	return &reader
+	counter := &byteCounter{reader: r}
+	reader := &TelematicsReader{checksum: utils.Checksum{Table: utils.CRC8[:]}, counter: counter}
+	reader.reader = io.TeeReader(counter, &reader.checksum)
+	return reader
 }
 
 func (r *TelematicsReader) skip(c byte) {
@@ -82,6 +86,9 @@ func (r *TelematicsReader) ReadCommon(v *value.Common) {
 	var flags [8]byte
 	v.Load(&flags)
 	for _, flag := range flags {
+		if flag == 0 {
+			continue
+		}
 		switch flag {
 		case value.COMMON_FLAG_STATE:
 			r.ReadBoolean(&v.State)
@@ -134,57 +141,126 @@ func (r *TelematicsReader) ReadGps(v *value.Gps) {
 		case value.GPS_FLAG_SATELLITES:
 			v.Set(value.GPS_FLAG_SATELLITES, true)
 			binary.Read(r.reader, binary.LittleEndian, &v.Sat)
+		case value.GPS_FLAG_DOP:
+			v.Set(value.GPS_FLAG_DOP, true)
+			var val uint16
+			binary.Read(r.reader, binary.LittleEndian, &val)
+			v.Hdop = float32(val) / 100.0
+			binary.Read(r.reader, binary.LittleEndian, &val)
+			v.Pdop = float32(val) / 100.0
+		case value.GPS_FLAG_FIX:
+			v.Set(value.GPS_FLAG_FIX, true)
+			binary.Read(r.reader, binary.LittleEndian, &v.Fix)
+			r.ReadBoolean(&v.Valid)
+			binary.Read(r.reader, binary.LittleEndian, &v.Constellation)
+		case value.GPS_FLAG_EXTENDED:
+			v.Set(value.GPS_FLAG_EXTENDED, true)
+			r.readGpsExtended(v)
 		default:
 			panic("gpsData flag not supported")
 		}
 	}
 }
 
-func (r *TelematicsReader) ReadGsm(v *value.Gsm) {
-	var mcc_mnc int32
-	r.ReadInt24(&mcc_mnc)
-	mcc_mnc_str := fmt.Sprintf("%d", mcc_mnc)
-	if len(mcc_mnc_str) < 3 {
-		v.MCC = mcc_mnc_str
-		v.MNC = "0"
-		if len(v.MCC) == 0 {
-			v.MCC = "0"
+func (r *TelematicsReader) readGpsExtended(v *value.Gps) {
+	binary.Read(r.reader, binary.LittleEndian, &v.Extended)
+	var flags [8]byte
+	v.Extended.Load(&flags)
+	for _, flag := range flags {
+		if flag == 0 {
+			continue
+		}
+		switch flag {
+		case value.GPS_EXTFLAG_SPEED:
+			var val uint16
+			binary.Read(r.reader, binary.LittleEndian, &val)
+			v.PreciseSpeed = float32(val) / 100.0
+		case value.GPS_EXTFLAG_COURSE:
+			var val uint16
+			binary.Read(r.reader, binary.LittleEndian, &val)
+			v.PreciseCourse = float32(val) / 100.0
+		default:
+			panic("gpsData extended flag not supported")
 		}
-	} else {
-		v.MCC = mcc_mnc_str[:3]
-		v.MNC = mcc_mnc_str[3:]
 	}
+}
+
+func (r *TelematicsReader) ReadGsm(v *value.Gsm) {
+	var mcc_mnc uint32
+	r.ReadUInt24(&mcc_mnc)
+	v.MCC, v.MNC = value.SplitMccMnc(mcc_mnc)
 
 	binary.Read(r.reader, binary.LittleEndian, &v.LAC)
 	binary.Read(r.reader, binary.LittleEndian, &v.CID)
 	binary.Read(r.reader, binary.LittleEndian, &v.Signal)
 }
 
+func (r *TelematicsReader) ReadCellInfo(v *value.CellInfo) {
+	var mcc_mnc uint32
+	r.ReadUInt24(&mcc_mnc)
+	v.MCC, v.MNC = value.SplitMccMnc(mcc_mnc)
+
+	r.ReadCell(&v.Serving)
+	var c byte
+	binary.Read(r.reader, binary.LittleEndian, &c)
+	v.Neighbors = make([]value.Cell, int(c))
+	for i := range v.Neighbors {
+		r.ReadCell(&v.Neighbors[i])
+	}
+}
+
+func (r *TelematicsReader) ReadCell(v *value.Cell) {
+	binary.Read(r.reader, binary.LittleEndian, &v.Radio)
+	if v.Radio.HasTAC() {
+		binary.Read(r.reader, binary.LittleEndian, &v.TAC)
+	} else {
+		binary.Read(r.reader, binary.LittleEndian, &v.LAC)
+	}
+	binary.Read(r.reader, binary.LittleEndian, &v.CID)
+	binary.Read(r.reader, binary.LittleEndian, &v.Signal)
+}
+
 func (r *TelematicsReader) ReadAcceleration(v *value.Acceleration) {
 	binary.Read(r.reader, binary.LittleEndian, &v.Flags8)
 	var flags [8]byte
 	v.Load(&flags)
-	var axis int16
-	var mult float32 = 1000.0
 	for _, flag := range flags {
+		if flag == 0 {
+			continue
+		}
 		switch flag {
 		case value.ACCELERATION_FLAG_X:
-			binary.Read(r.reader, binary.LittleEndian, &axis)
-			v.AxisX = float32(axis) / mult
+			v.AxisX = r.readAxis(1000.0)
 		case value.ACCELERATION_FLAG_Y:
-			binary.Read(r.reader, binary.LittleEndian, &axis)
-			v.AxisY = float32(axis) / mult
+			v.AxisY = r.readAxis(1000.0)
 		case value.ACCELERATION_FLAG_Z:
-			binary.Read(r.reader, binary.LittleEndian, &axis)
-			v.AxisZ = float32(axis) / mult
+			v.AxisZ = r.readAxis(1000.0)
 		case value.ACCELERATION_FLAG_DURATION:
 			binary.Read(r.reader, binary.LittleEndian, &v.Duration)
+		case value.ACCELERATION_FLAG_GYRO_X:
+			v.GyroX = r.readAxis(10.0)
+		case value.ACCELERATION_FLAG_GYRO_Y:
+			v.GyroY = r.readAxis(10.0)
+		case value.ACCELERATION_FLAG_GYRO_Z:
+			v.GyroZ = r.readAxis(10.0)
 		default:
 			panic(fmt.Sprintf("acceleration flag %X not supported", flag))
 		}
+		v.Set(flag, true)
 	}
 }
 
+func (r *TelematicsReader) readAxis(scale float32) float32 {
+	var axis int16
+	binary.Read(r.reader, binary.LittleEndian, &axis)
+	return float32(axis) / scale
+}
+
+func (r *TelematicsReader) ReadIoPort(v *value.IoPort) {
+	binary.Read(r.reader, binary.LittleEndian, &v.Flags)
+	binary.Read(r.reader, binary.LittleEndian, &v.State)
+}
+
 func (r *TelematicsReader) ReadRgb(v *value.Rgb) {
 	binary.Read(r.reader, binary.LittleEndian, &v.R)
 	binary.Read(r.reader, binary.LittleEndian, &v.G)
@@ -203,7 +279,7 @@ func (r *TelematicsReader) ReadNameValue(dataType value.DataType, v *value.NameV
 func (r *TelematicsReader) ReadNameValues(dataType value.DataType) []value.NameValue {
 	var c byte
 	binary.Read(r.reader, binary.LittleEndian, &c)
-	result := make([]value.NameValue, int(c))
+	result := make([]value.NameValue, 0, int(c))
 	for i := byte(0); i < c; i++ {
 		var v value.NameValue
 		r.ReadNameValue(dataType, &v)
@@ -8,12 +8,28 @@ import (
 	"github.com/boiledgas/protocol/telematics/value"
 )
 
+var (
+	ErrCrcNotValid    = errors.New("crc not valid")
+	ErrSectionUnknown = errors.New("section not found")
+)
+
 const (
 	PACKET_TYPE_REQUEST  byte = 0xAA
 	PACKET_TYPE_RESPONSE byte = 0xCC
 )
 
 func (r *TelematicsReader) Read(packet *Packet) (err error) {
+	if r.Metrics != nil {
+		start := r.counter.count()
+		defer func() {
+			rec := recover()
+			r.measure(packet, r.counter.count()-start, err, rec != nil)
+			if rec != nil {
+				panic(rec)
+			}
+		}()
+	}
+	r.checksum.Compute() // checksum starts with packet
 	var pt byte
 	if err = r.ReadByte(&pt); err != nil {
 		return
@@ -34,6 +50,7 @@ func (r *TelematicsReader) Read(packet *Packet) (err error) {
 }
 
 func (r *TelematicsReader) ReadRequest(req *Request) (err error) {
+	r.checksum.Compute() // checksum starts with packet
 	var pt byte
 	if err := binary.Read(r.reader, binary.BigEndian, &pt); err != nil {
 		return err
@@ -112,7 +129,7 @@ sections:
 			r.ReadCommandExecute(&ce)
 			req.Executes = append(req.Executes, ce)
 		default:
-			err = errors.New("section not found")
+			err = ErrSectionUnknown
 			return
 		}
 		req.Set(t.Flag(), true)
@@ -125,12 +142,20 @@ sections:
 
 	delta := r.checksum.Compute()
 	if delta != 0 {
-		err = errors.New("crc not valid")
+		err = ErrCrcNotValid
+		return
+	}
+	if r.Validate && r.Configuration != nil {
+		v := Validator{Configuration: r.Configuration}
+		if violations := v.Validate(req); len(violations) > 0 {
+			err = &ValidationError{Violations: violations}
+		}
 	}
 	return
 }
 
 func (r *TelematicsReader) ReadResponse(response *Response) (err error) {
+	r.checksum.Compute() // checksum starts with packet
 	var pt byte
 	if err := binary.Read(r.reader, binary.BigEndian, &pt); err != nil {
 		return err
@@ -149,6 +174,9 @@ func (r *TelematicsReader) readResponse(response *Response) (err error) {
 	if err = binary.Read(r.reader, binary.LittleEndian, &response.Flags); err != nil {
 		panic("flags")
 	}
+	if response.Has(RESPONSE_CHALLENGE) {
+		response.Nonce = r.ReadBytes()
+	}
 	if err = binary.Read(r.reader, binary.LittleEndian, &response.Crc); err != nil {
 		panic("checksum")
 	}
@@ -204,6 +232,10 @@ func (r *TelematicsReader) ReadAuthentication(s *section.Authentication) {
 			s.Identifier = r.ReadString()
 		case section.AUTHENTICATION_FLAGS_SECRET:
 			s.Secret = r.ReadBytes()
+		case section.AUTHENTICATION_FLAGS_NONCE:
+			s.Nonce = r.ReadBytes()
+		case section.AUTHENTICATION_FLAGS_DIGEST:
+			s.Digest = r.ReadBytes()
 		default:
 			panic("flag not supported")
 		}
@@ -243,9 +275,9 @@ func (r *TelematicsReader) ReadModuleProperty(mp *section.ModuleProperty) {
 		}
 		switch flag {
 		case section.MODULE_PROPERTY_FLAGS_MIN:
-			binary.Read(r.reader, binary.LittleEndian, &mp.Min)
+			mp.Min = r.readData(mp.Type)
 		case section.MODULE_PROPERTY_FLAGS_MAX:
-			binary.Read(r.reader, binary.LittleEndian, &mp.Max)
+			mp.Max = r.readData(mp.Type)
 		case section.MODULE_PROPERTY_FLAGS_LIST:
 			mp.List = r.ReadNameValues(mp.Type)
 		case section.MODULE_PROPERTY_FLAGS_ACCESS:
@@ -260,6 +292,7 @@ func (r *TelematicsReader) ReadModuleProperty(mp *section.ModuleProperty) {
 
 func (r *TelematicsReader) ReadModulePropertyValue(s *section.ModulePropertyValue) (err error) {
 	s.Values = make(map[byte]interface{})
+	s.Types = make(map[byte]value.DataType)
 	binary.Read(r.reader, binary.LittleEndian, &s.ModuleId)
 	var c, i byte
 	binary.Read(r.reader, binary.LittleEndian, &c)
@@ -269,11 +302,12 @@ func (r *TelematicsReader) ReadModulePropertyValue(s *section.ModulePropertyValu
 
 		var p section.ModuleProperty
 		if !r.Configuration.GetProperty(s.ModuleId, id, &p) {
-			err = errors.New(fmt.Sprintf("property not found: %v %v", s.ModuleId, id))
+			err = fmt.Errorf("%w: %v %v", ErrPropertyUnknown, s.ModuleId, id)
 			return
 		}
 
 		s.Values[id] = r.readData(p.Type)
+		s.Types[id] = p.Type
 	}
 	return
 }
@@ -340,6 +374,12 @@ func (r *TelematicsReader) ReadCommandArgument(ca *section.CommandArgument) {
 func (r *TelematicsReader) ReadCommandExecute(ce *section.CommandExecute) {
 	binary.Read(r.reader, binary.LittleEndian, &ce.ModuleId)
 	binary.Read(r.reader, binary.LittleEndian, &ce.CommandId)
+	if ce.Arguments == nil {
+		ce.Arguments = make(map[byte]interface{})
+	}
+	if ce.Types == nil {
+		ce.Types = make(map[byte]value.DataType)
+	}
 
 	var c byte
 	binary.Read(r.reader, binary.LittleEndian, &c)
@@ -353,6 +393,7 @@ func (r *TelematicsReader) ReadCommandExecute(ce *section.CommandExecute) {
 		}
 
 		ce.Arguments[id] = r.readData(arg.Type)
+		ce.Types[id] = arg.Type
 	}
 }
 
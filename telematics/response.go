@@ -6,11 +6,18 @@ const (
 	RESPONSE_OK            ResponseFlag = 0x00
 	RESPONSE_AUTHORIZATION ResponseFlag = 0x01
 	RESPONSE_DESCRIPTION   ResponseFlag = 0x02
+	RESPONSE_EXECUTED      ResponseFlag = 0x04 // commands of request are executed, not only received
+	RESPONSE_CHALLENGE     ResponseFlag = 0x08 // response carries nonce for challenge authentication
 	RESPONSE_ERROR         ResponseFlag = 0x80
 )
 
 type Response struct {
 	Flags    ResponseFlag
 	Sequence byte
+	Nonce    []byte
 	Crc      byte
 }
+
+func (r Response) Has(flag ResponseFlag) bool {
+	return r.Flags&flag == flag
+}
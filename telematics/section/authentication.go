@@ -9,14 +9,19 @@ import (
 const (
 	AUTHENTICATION_FLAGS_IDENTIFIER byte = 0x01
 	AUTHENTICATION_FLAGS_SECRET          = 0x02
+	AUTHENTICATION_FLAGS_NONCE           = 0x04 // nonce of server response the digest is computed for
+	AUTHENTICATION_FLAGS_DIGEST          = 0x08 // HMAC-SHA256 of nonce, identifier and request sequence keyed with secret
 )
 
 type Authentication struct {
 	utils.Flags8
 	Identifier string
 	Secret     []byte
+	Nonce      []byte
+	Digest     []byte
 }
 
 func (s Authentication) String() string {
-	return fmt.Sprintf("%v", s)
+	// secret and digest are not printed
+	return fmt.Sprintf("{Flags:%v; Identifier:%v; Nonce:%x}", s.Flags8, s.Identifier, s.Nonce)
 }
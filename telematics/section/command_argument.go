@@ -14,6 +14,7 @@ const (
 	COMMAND_ARGUMENT_FLAGS_REQUIRED    byte = 0x08
 	COMMAND_ARGUMENT_FLAGS_NAME        byte = 0x10
 	COMMAND_ARGUMENT_FLAGS_DESCRIPTION byte = 0x20
+	COMMAND_ARGUMENT_FLAGS_BITMASK     byte = 0x40 // list values are bits, no payload, extension not sent by device firmware
 )
 
 type CommandArgument struct {
@@ -1,13 +1,17 @@
 package section
 
-import "fmt"
+import (
+	"fmt"
+	"github.com/boiledgas/protocol/telematics/value"
+)
 
 type CommandExecute struct {
 	ModuleId  byte
 	CommandId byte
 	Arguments map[byte]interface{}
+	Types     map[byte]value.DataType // argument data types, filled on read
 }
 
 func (s CommandExecute) String() string {
-	return fmt.Sprintf("%v", s)
+	return fmt.Sprintf("{ModuleId:%v; CommandId:%v; Arguments:%v}", s.ModuleId, s.CommandId, s.Arguments)
 }
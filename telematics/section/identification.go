@@ -20,6 +20,28 @@ const (
 	DEVICETYPE_CARBEACON    DeviceType = 0x07
 )
 
+func (t DeviceType) String() string {
+	switch t {
+	case DEVICETYPE_NOTSPECIFIED:
+		return "NotSpecified"
+	case DEVICETYPE_APPLICATION:
+		return "Application"
+	case DEVICETYPE_PERSONAL:
+		return "Personal"
+	case DEVICETYPE_STATIONARY:
+		return "Stationary"
+	case DEVICETYPE_CAR:
+		return "Car"
+	case DEVICETYPE_CAROBD:
+		return "CarObd"
+	case DEVICETYPE_CARSOCKET:
+		return "CarSocket"
+	case DEVICETYPE_CARBEACON:
+		return "CarBeacon"
+	}
+	return ""
+}
+
 // identification flags
 const (
 	IDENTIFICATION_FLAGS_CODE       byte = 0x01
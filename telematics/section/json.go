@@ -0,0 +1,504 @@
+package section
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/boiledgas/protocol/telematics/value"
+	"github.com/boiledgas/protocol/utils"
+)
+
+// MarshalJSON writes name of section type, unknown section type is written as number
+func (t Type) MarshalJSON() ([]byte, error) {
+	if name := t.String(); name != "" {
+		return json.Marshal(name)
+	}
+	return json.Marshal(byte(t))
+}
+
+func (t *Type) UnmarshalJSON(data []byte) error {
+	var number byte
+	if json.Unmarshal(data, &number) == nil {
+		*t = Type(number)
+		return nil
+	}
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	if name == "" {
+		return fmt.Errorf("unknown section type %q", name)
+	}
+	for i := 0; i < 256; i++ {
+		if Type(i).String() == name {
+			*t = Type(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown section type %v", name)
+}
+
+// MarshalJSON writes name of device type, unknown device type is written as number
+func (t DeviceType) MarshalJSON() ([]byte, error) {
+	if name := t.String(); name != "" {
+		return json.Marshal(name)
+	}
+	return json.Marshal(byte(t))
+}
+
+func (t *DeviceType) UnmarshalJSON(data []byte) error {
+	var number byte
+	if json.Unmarshal(data, &number) == nil {
+		*t = DeviceType(number)
+		return nil
+	}
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	for dt := DEVICETYPE_NOTSPECIFIED; dt <= DEVICETYPE_CARBEACON; dt++ {
+		if dt.String() == name {
+			*t = dt
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown device type %v", name)
+}
+
+var identificationFlags = []utils.FlagName{
+	{Flag: uint16(IDENTIFICATION_FLAGS_CODE), Name: "code"},
+	{Flag: uint16(IDENTIFICATION_FLAGS_CODETEXT), Name: "codeText"},
+	{Flag: uint16(IDENTIFICATION_FLAGS_DEVICETYPE), Name: "deviceType"},
+	{Flag: uint16(IDENTIFICATION_FLAGS_FIRMWARE), Name: "firmware"},
+	{Flag: uint16(IDENTIFICATION_FLAGS_HARDWARE), Name: "hardware"},
+	{Flag: uint16(IDENTIFICATION_FLAGS_DEVICEHASH), Name: "deviceHash"},
+}
+
+type identificationJSON struct {
+	Flags    map[string]bool `json:"flags"`
+	Code     uint32          `json:"code,omitempty"`
+	CodeText string          `json:"codeText,omitempty"`
+	Type     DeviceType      `json:"deviceType"`
+	Firmware int16           `json:"firmware,omitempty"`
+	Hardware int16           `json:"hardware,omitempty"`
+	Hash     byte            `json:"deviceHash,omitempty"`
+}
+
+func (s Identification) MarshalJSON() ([]byte, error) {
+	return json.Marshal(identificationJSON{
+		Flags:    s.Named(identificationFlags),
+		Code:     s.Code,
+		CodeText: s.CodeText,
+		Type:     s.Type,
+		Firmware: s.Firmware,
+		Hardware: s.Hardware,
+		Hash:     s.Hash,
+	})
+}
+
+func (s *Identification) UnmarshalJSON(data []byte) error {
+	var j identificationJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	*s = Identification{Code: j.Code, CodeText: j.CodeText, Type: j.Type, Firmware: j.Firmware, Hardware: j.Hardware, Hash: j.Hash}
+	return s.SetNamed(identificationFlags, j.Flags)
+}
+
+var authenticationFlags = []utils.FlagName{
+	{Flag: uint16(AUTHENTICATION_FLAGS_IDENTIFIER), Name: "identifier"},
+	{Flag: uint16(AUTHENTICATION_FLAGS_SECRET), Name: "secret"},
+	{Flag: uint16(AUTHENTICATION_FLAGS_NONCE), Name: "nonce"},
+	{Flag: uint16(AUTHENTICATION_FLAGS_DIGEST), Name: "digest"},
+}
+
+type authenticationJSON struct {
+	Flags      map[string]bool `json:"flags"`
+	Identifier string          `json:"identifier,omitempty"`
+	Secret     []byte          `json:"secret,omitempty"`
+	Nonce      []byte          `json:"nonce,omitempty"`
+	Digest     []byte          `json:"digest,omitempty"`
+}
+
+func (s Authentication) MarshalJSON() ([]byte, error) {
+	return json.Marshal(authenticationJSON{
+		Flags:      s.Named(authenticationFlags),
+		Identifier: s.Identifier,
+		Secret:     s.Secret,
+		Nonce:      s.Nonce,
+		Digest:     s.Digest,
+	})
+}
+
+func (s *Authentication) UnmarshalJSON(data []byte) error {
+	var j authenticationJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	*s = Authentication{Identifier: j.Identifier, Secret: j.Secret, Nonce: j.Nonce, Digest: j.Digest}
+	return s.SetNamed(authenticationFlags, j.Flags)
+}
+
+func (s Supported) MarshalJSON() ([]byte, error) {
+	var flags [16]uint16
+	s.Load(&flags)
+	types := []Type{}
+	for _, flag := range flags {
+		if flag == 0 {
+			continue
+		}
+		types = append(types, ToSectionType(flag))
+	}
+	return json.Marshal(types)
+}
+
+func (s *Supported) UnmarshalJSON(data []byte) error {
+	var types []Type
+	if err := json.Unmarshal(data, &types); err != nil {
+		return err
+	}
+	*s = Supported{}
+	for _, t := range types {
+		if t == SECTION_UNKNOWN || t == SECTION_ENDOFPAYLOAD {
+			return fmt.Errorf("section %v can't be supported", t)
+		}
+		s.Support(t, true)
+	}
+	return nil
+}
+
+var moduleFlags = []utils.FlagName{
+	{Flag: uint16(MODULE_FLAGS_NAME), Name: "name"},
+	{Flag: uint16(MODULE_FLAGS_DESCRIPTION), Name: "description"},
+}
+
+type moduleJSON struct {
+	Flags       map[string]bool `json:"flags"`
+	Id          byte            `json:"id"`
+	Name        string          `json:"name,omitempty"`
+	Description string          `json:"description,omitempty"`
+}
+
+func (m Module) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moduleJSON{
+		Flags:       m.Named(moduleFlags),
+		Id:          m.Id,
+		Name:        m.Name,
+		Description: m.Description,
+	})
+}
+
+func (m *Module) UnmarshalJSON(data []byte) error {
+	var j moduleJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	*m = Module{Id: j.Id, Name: j.Name, Description: j.Description}
+	return m.SetNamed(moduleFlags, j.Flags)
+}
+
+var modulePropertyFlags = []utils.FlagName{
+	{Flag: uint16(MODULE_PROPERTY_FLAGS_MIN), Name: "min"},
+	{Flag: uint16(MODULE_PROPERTY_FLAGS_MAX), Name: "max"},
+	{Flag: uint16(MODULE_PROPERTY_FLAGS_LIST), Name: "list"},
+	{Flag: uint16(MODULE_PROPERTY_FLAGS_ACCESS), Name: "access"},
+	{Flag: uint16(MODULE_PROPERTY_FLAGS_NAME), Name: "name"},
+	{Flag: uint16(MODULE_PROPERTY_FLAGS_DESCRIPTION), Name: "description"},
+	{Flag: uint16(MODULE_PROPERTY_FLAGS_BITMASK), Name: "bitmask"},
+}
+
+var propertyAccessFlags = []utils.FlagName{
+	{Flag: uint16(PROPERTYACCESS_READ), Name: "read"},
+	{Flag: uint16(PROPERTYACCESS_WRITE), Name: "write"},
+	{Flag: uint16(PROPERTYACCESS_CONFIG), Name: "config"},
+	{Flag: uint16(PROPERTYACCESS_DISABLED), Name: "disabled"},
+}
+
+func (a PropertyAccess) MarshalJSON() ([]byte, error) {
+	flags := utils.Flags8(a)
+	return json.Marshal(flags.Named(propertyAccessFlags))
+}
+
+func (a *PropertyAccess) UnmarshalJSON(data []byte) error {
+	var named map[string]bool
+	if err := json.Unmarshal(data, &named); err != nil {
+		return err
+	}
+	var flags utils.Flags8
+	if err := flags.SetNamed(propertyAccessFlags, named); err != nil {
+		return err
+	}
+	*a = PropertyAccess(flags)
+	return nil
+}
+
+type modulePropertyJSON struct {
+	Flags    map[string]bool   `json:"flags"`
+	ModuleId byte              `json:"moduleId"`
+	Id       byte              `json:"id"`
+	Type     value.DataType    `json:"type"`
+	Min      json.RawMessage   `json:"min,omitempty"`
+	Max      json.RawMessage   `json:"max,omitempty"`
+	List     []value.NameValue `json:"list,omitempty"`
+	Access   PropertyAccess    `json:"access"`
+	Name     string            `json:"name,omitempty"`
+	Desc     string            `json:"description,omitempty"`
+}
+
+func (m ModuleProperty) MarshalJSON() (data []byte, err error) {
+	j := modulePropertyJSON{
+		Flags:    m.Named(modulePropertyFlags),
+		ModuleId: m.ModuleId,
+		Id:       m.Id,
+		Type:     m.Type,
+		List:     m.List,
+		Access:   m.Access,
+		Name:     m.Name,
+		Desc:     m.Desc,
+	}
+	if j.Min, err = marshalLimit(m.Type, m.Min); err != nil {
+		return
+	}
+	if j.Max, err = marshalLimit(m.Type, m.Max); err != nil {
+		return
+	}
+	return json.Marshal(j)
+}
+
+func (m *ModuleProperty) UnmarshalJSON(data []byte) (err error) {
+	var j struct {
+		modulePropertyJSON
+		List json.RawMessage `json:"list,omitempty"`
+	}
+	if err = json.Unmarshal(data, &j); err != nil {
+		return
+	}
+	*m = ModuleProperty{ModuleId: j.ModuleId, Id: j.Id, Type: j.Type, Access: j.Access, Name: j.Name, Desc: j.Desc}
+	if m.Min, err = unmarshalLimit(m.Type, j.Min); err != nil {
+		return
+	}
+	if m.Max, err = unmarshalLimit(m.Type, j.Max); err != nil {
+		return
+	}
+	if len(j.List) > 0 {
+		if m.List, err = value.UnmarshalNameValues(m.Type, j.List); err != nil {
+			return
+		}
+	}
+	return m.SetNamed(modulePropertyFlags, j.Flags)
+}
+
+// ModulePropertyValue json, data types are taken from Types or go types of values
+func (s ModulePropertyValue) MarshalJSON() ([]byte, error) {
+	values, err := marshalTyped(s.Values, s.Types)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(struct {
+		ModuleId byte              `json:"moduleId"`
+		Values   []value.TypedJSON `json:"values"`
+	}{s.ModuleId, values})
+}
+
+func (s *ModulePropertyValue) UnmarshalJSON(data []byte) (err error) {
+	var j struct {
+		ModuleId byte              `json:"moduleId"`
+		Values   []value.TypedJSON `json:"values"`
+	}
+	if err = json.Unmarshal(data, &j); err != nil {
+		return
+	}
+	s.ModuleId = j.ModuleId
+	s.Values, s.Types, err = unmarshalTyped(j.Values)
+	return
+}
+
+type modulePropertyDisableJSON struct {
+	DisabledProperties map[byte]byte `json:"disabledProperties"`
+}
+
+func (s ModulePropertyDisable) MarshalJSON() ([]byte, error) {
+	return json.Marshal(modulePropertyDisableJSON(s))
+}
+
+func (s *ModulePropertyDisable) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*modulePropertyDisableJSON)(s))
+}
+
+var commandFlags = []utils.FlagName{
+	{Flag: uint16(COMMAND_FLAGS_NAME), Name: "name"},
+	{Flag: uint16(COMMAND_FLAGS_DESCRIPTION), Name: "description"},
+}
+
+type commandJSON struct {
+	Flags       map[string]bool `json:"flags"`
+	ModuleId    byte            `json:"moduleId"`
+	Id          byte            `json:"id"`
+	Name        string          `json:"name,omitempty"`
+	Description string          `json:"description,omitempty"`
+}
+
+func (c Command) MarshalJSON() ([]byte, error) {
+	return json.Marshal(commandJSON{
+		Flags:       c.Named(commandFlags),
+		ModuleId:    c.ModuleId,
+		Id:          c.Id,
+		Name:        c.Name,
+		Description: c.Description,
+	})
+}
+
+func (c *Command) UnmarshalJSON(data []byte) error {
+	var j commandJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	*c = Command{ModuleId: j.ModuleId, Id: j.Id, Name: j.Name, Description: j.Description}
+	return c.SetNamed(commandFlags, j.Flags)
+}
+
+var commandArgumentFlags = []utils.FlagName{
+	{Flag: uint16(COMMAND_ARGUMENT_FLAGS_MIN), Name: "min"},
+	{Flag: uint16(COMMAND_ARGUMENT_FLAGS_MAX), Name: "max"},
+	{Flag: uint16(COMMAND_ARGUMENT_FLAGS_LIST), Name: "list"},
+	{Flag: uint16(COMMAND_ARGUMENT_FLAGS_REQUIRED), Name: "required"},
+	{Flag: uint16(COMMAND_ARGUMENT_FLAGS_NAME), Name: "name"},
+	{Flag: uint16(COMMAND_ARGUMENT_FLAGS_DESCRIPTION), Name: "description"},
+	{Flag: uint16(COMMAND_ARGUMENT_FLAGS_BITMASK), Name: "bitmask"},
+}
+
+type commandArgumentJSON struct {
+	Flags     map[string]bool   `json:"flags"`
+	ModuleId  byte              `json:"moduleId"`
+	CommandId byte              `json:"commandId"`
+	Id        byte              `json:"id"`
+	Type      value.DataType    `json:"type"`
+	Min       json.RawMessage   `json:"min,omitempty"`
+	Max       json.RawMessage   `json:"max,omitempty"`
+	List      []value.NameValue `json:"list,omitempty"`
+	Required  byte              `json:"required"`
+	Name      string            `json:"name,omitempty"`
+	Desc      string            `json:"description,omitempty"`
+}
+
+func (ca CommandArgument) MarshalJSON() (data []byte, err error) {
+	j := commandArgumentJSON{
+		Flags:     ca.Named(commandArgumentFlags),
+		ModuleId:  ca.ModuleId,
+		CommandId: ca.CommandId,
+		Id:        ca.Id,
+		Type:      ca.Type,
+		List:      ca.List,
+		Required:  ca.Required,
+		Name:      ca.Name,
+		Desc:      ca.Desc,
+	}
+	if j.Min, err = marshalLimit(ca.Type, ca.Min); err != nil {
+		return
+	}
+	if j.Max, err = marshalLimit(ca.Type, ca.Max); err != nil {
+		return
+	}
+	return json.Marshal(j)
+}
+
+func (ca *CommandArgument) UnmarshalJSON(data []byte) (err error) {
+	var j struct {
+		commandArgumentJSON
+		List json.RawMessage `json:"list,omitempty"`
+	}
+	if err = json.Unmarshal(data, &j); err != nil {
+		return
+	}
+	*ca = CommandArgument{ModuleId: j.ModuleId, CommandId: j.CommandId, Id: j.Id, Type: j.Type, Required: j.Required, Name: j.Name, Desc: j.Desc}
+	if ca.Min, err = unmarshalLimit(ca.Type, j.Min); err != nil {
+		return
+	}
+	if ca.Max, err = unmarshalLimit(ca.Type, j.Max); err != nil {
+		return
+	}
+	if len(j.List) > 0 {
+		if ca.List, err = value.UnmarshalNameValues(ca.Type, j.List); err != nil {
+			return
+		}
+	}
+	return ca.SetNamed(commandArgumentFlags, j.Flags)
+}
+
+// CommandExecute json, data types are taken from Types or go types of arguments
+func (s CommandExecute) MarshalJSON() ([]byte, error) {
+	arguments, err := marshalTyped(s.Arguments, s.Types)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(struct {
+		ModuleId  byte              `json:"moduleId"`
+		CommandId byte              `json:"commandId"`
+		Arguments []value.TypedJSON `json:"arguments"`
+	}{s.ModuleId, s.CommandId, arguments})
+}
+
+func (s *CommandExecute) UnmarshalJSON(data []byte) (err error) {
+	var j struct {
+		ModuleId  byte              `json:"moduleId"`
+		CommandId byte              `json:"commandId"`
+		Arguments []value.TypedJSON `json:"arguments"`
+	}
+	if err = json.Unmarshal(data, &j); err != nil {
+		return
+	}
+	s.ModuleId, s.CommandId = j.ModuleId, j.CommandId
+	s.Arguments, s.Types, err = unmarshalTyped(j.Arguments)
+	return
+}
+
+func marshalLimit(t value.DataType, v interface{}) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return value.MarshalData(t, v)
+}
+
+func unmarshalLimit(t value.DataType, data json.RawMessage) (interface{}, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	return value.UnmarshalData(t, data)
+}
+
+func marshalTyped(values map[byte]interface{}, types map[byte]value.DataType) (result []value.TypedJSON, err error) {
+	ids := make([]int, 0, len(values))
+	for id := range values {
+		ids = append(ids, int(id))
+	}
+	sort.Ints(ids)
+	result = make([]value.TypedJSON, 0, len(ids))
+	for _, id := range ids {
+		v := values[byte(id)]
+		t, ok := types[byte(id)]
+		if !ok {
+			t = value.TypeOf(v)
+		}
+		typed := value.TypedJSON{Id: byte(id), Type: t}
+		if typed.Value, err = value.MarshalData(t, v); err != nil {
+			return
+		}
+		result = append(result, typed)
+	}
+	return
+}
+
+func unmarshalTyped(list []value.TypedJSON) (values map[byte]interface{}, types map[byte]value.DataType, err error) {
+	values = make(map[byte]interface{}, len(list))
+	types = make(map[byte]value.DataType, len(list))
+	for _, typed := range list {
+		var v interface{}
+		if v, err = value.UnmarshalData(typed.Type, typed.Value); err != nil {
+			return
+		}
+		values[typed.Id] = v
+		types[typed.Id] = typed.Type
+	}
+	return
+}
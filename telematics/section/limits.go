@@ -0,0 +1,173 @@
+package section
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/boiledgas/protocol/telematics/value"
+)
+
+var (
+	ErrBelowMin  = errors.New("value below minimum")
+	ErrAboveMax  = errors.New("value above maximum")
+	ErrNotInList = errors.New("value not in list")
+)
+
+// limits of property or command argument, flags tell which are set
+type limits struct {
+	Type    value.DataType
+	Min     interface{}
+	Max     interface{}
+	List    []value.NameValue
+	HasMin  bool
+	HasMax  bool
+	HasList bool
+	Bitmask bool // several list values can be set
+}
+
+// separator of bitmask labels
+const LABEL_SEPARATOR = "|"
+
+// check converts v to go type of limits data type and checks it against Min, Max and List
+func (l limits) check(v interface{}) (interface{}, error) {
+	v, err := l.parse(v)
+	if err != nil {
+		return nil, err
+	}
+	if v, err = value.Convert(l.Type, v); err != nil {
+		return nil, err
+	}
+	if l.HasMin && l.Min != nil {
+		if c, err := value.Compare(v, l.Min); err != nil {
+			return nil, err
+		} else if c < 0 {
+			return nil, fmt.Errorf("%v < %v: %w", v, l.Min, ErrBelowMin)
+		}
+	}
+	if l.HasMax && l.Max != nil {
+		if c, err := value.Compare(v, l.Max); err != nil {
+			return nil, err
+		} else if c > 0 {
+			return nil, fmt.Errorf("%v > %v: %w", v, l.Max, ErrAboveMax)
+		}
+	}
+	if l.HasList && len(l.List) > 0 {
+		found := false
+		if l.Bitmask {
+			found = value.IsMask(l.List, v)
+		} else {
+			_, found = value.Label(l.List, v)
+		}
+		if !found {
+			return nil, fmt.Errorf("%v: %w", v, ErrNotInList)
+		}
+	}
+	return v, nil
+}
+
+// parse replaces labels of list with values, string data types are not parsed
+func (l limits) parse(v interface{}) (interface{}, error) {
+	if !l.HasList || len(l.List) == 0 {
+		return v, nil
+	}
+	if _, ok := value.Zero(l.Type).(string); ok {
+		return v, nil
+	}
+	var labels []string
+	switch v := v.(type) {
+	case string:
+		if !l.Bitmask {
+			if res, ok := value.Lookup(l.List, v); ok {
+				return res, nil
+			}
+			return nil, fmt.Errorf("label %v: %w", v, ErrNotInList)
+		}
+		if v != "" {
+			labels = strings.Split(v, LABEL_SEPARATOR)
+		}
+	case []string:
+		if !l.Bitmask {
+			return nil, fmt.Errorf("labels %v of not bitmask list", v)
+		}
+		labels = append([]string(nil), v...) // labels are trimmed in place
+	default:
+		return v, nil
+	}
+	for i := range labels {
+		labels[i] = strings.TrimSpace(labels[i])
+	}
+	res, err := value.Mask(l.Type, l.List, labels...)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", err, ErrNotInList)
+	}
+	return res, nil
+}
+
+// label returns list name of v, names of bitmask are joined with LABEL_SEPARATOR,
+// false if v or some of its bits are not in list
+func (l limits) label(v interface{}) (string, bool) {
+	if !l.Bitmask {
+		return value.Label(l.List, v)
+	}
+	names, rest, err := value.Labels(l.List, v)
+	if err != nil || rest != 0 {
+		return "", false
+	}
+	return strings.Join(names, LABEL_SEPARATOR), true
+}
+
+func (ca *CommandArgument) limits() limits {
+	return limits{
+		Type:    ca.Type,
+		Min:     ca.Min,
+		Max:     ca.Max,
+		List:    ca.List,
+		HasMin:  ca.Has(COMMAND_ARGUMENT_FLAGS_MIN),
+		HasMax:  ca.Has(COMMAND_ARGUMENT_FLAGS_MAX),
+		HasList: ca.Has(COMMAND_ARGUMENT_FLAGS_LIST),
+		Bitmask: ca.Has(COMMAND_ARGUMENT_FLAGS_BITMASK),
+	}
+}
+
+// Check converts v to argument type and checks Min, Max and List, labels of list are accepted
+func (ca *CommandArgument) Check(v interface{}) (interface{}, error) {
+	return ca.limits().check(v)
+}
+
+// Label returns list label of argument value
+func (ca *CommandArgument) Label(v interface{}) (string, bool) {
+	return ca.limits().label(v)
+}
+
+func (ca *CommandArgument) IsRequired() bool {
+	return ca.Has(COMMAND_ARGUMENT_FLAGS_REQUIRED) && ca.Required != 0
+}
+
+func (p *ModuleProperty) limits() limits {
+	return limits{
+		Type:    p.Type,
+		Min:     p.Min,
+		Max:     p.Max,
+		List:    p.List,
+		HasMin:  p.Has(MODULE_PROPERTY_FLAGS_MIN),
+		HasMax:  p.Has(MODULE_PROPERTY_FLAGS_MAX),
+		HasList: p.Has(MODULE_PROPERTY_FLAGS_LIST),
+		Bitmask: p.Has(MODULE_PROPERTY_FLAGS_BITMASK),
+	}
+}
+
+// Check converts v to property type and checks Min, Max and List, labels of list are accepted
+func (p *ModuleProperty) Check(v interface{}) (interface{}, error) {
+	return p.limits().check(v)
+}
+
+// Label returns list label of property value, "Driving" instead of 3
+func (p *ModuleProperty) Label(v interface{}) (string, bool) {
+	return p.limits().label(v)
+}
+
+// Parse returns property value of list label, bitmask labels are joined with LABEL_SEPARATOR
+func (p *ModuleProperty) Parse(label string) (interface{}, error) {
+	return p.limits().check(label)
+}
@@ -14,6 +14,7 @@ const (
 	MODULE_PROPERTY_FLAGS_ACCESS           = 0x08
 	MODULE_PROPERTY_FLAGS_NAME             = 0x10
 	MODULE_PROPERTY_FLAGS_DESCRIPTION      = 0x20
+	MODULE_PROPERTY_FLAGS_BITMASK          = 0x40 // list values are bits, no payload, extension not sent by device firmware
 )
 
 type PropertyAccess byte
@@ -44,3 +45,12 @@ type ModuleProperty struct {
 func (m ModuleProperty) String() string {
 	return fmt.Sprintf("{Id:%v; ModuleId:%v; Name:%v, Type:%v}", m.Id, m.ModuleId, m.Name, m.Type.String())
 }
+
+func (m *ModuleProperty) HasAccess(access PropertyAccess) bool {
+	return m.Has(MODULE_PROPERTY_FLAGS_ACCESS) && m.Access&access == access
+}
+
+// IsWritable returns true if server can set property value
+func (m *ModuleProperty) IsWritable() bool {
+	return (m.HasAccess(PROPERTYACCESS_WRITE) || m.HasAccess(PROPERTYACCESS_CONFIG)) && !m.HasAccess(PROPERTYACCESS_DISABLED)
+}
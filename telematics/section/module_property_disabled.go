@@ -1,11 +1,36 @@
 package section
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+)
 
+// ModulePropertyDisable lists disabled properties, key is module id and value is property id,
+// several properties of one module are sent in several sections
 type ModulePropertyDisable struct {
 	DisabledProperties map[byte]byte
 }
 
+// PropertyRef identifies property of module
+type PropertyRef struct {
+	ModuleId   byte
+	PropertyId byte
+}
+
+func (p PropertyRef) String() string {
+	return fmt.Sprintf("%v.%v", p.ModuleId, p.PropertyId)
+}
+
+// Properties returns disabled properties ordered by module id
+func (s ModulePropertyDisable) Properties() []PropertyRef {
+	res := make([]PropertyRef, 0, len(s.DisabledProperties))
+	for m, p := range s.DisabledProperties {
+		res = append(res, PropertyRef{ModuleId: m, PropertyId: p})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ModuleId < res[j].ModuleId })
+	return res
+}
+
 func (s ModulePropertyDisable) String() string {
-	return fmt.Sprintf("%v", s)
+	return fmt.Sprintf("{Disabled:%v}", s.Properties())
 }
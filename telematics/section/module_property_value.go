@@ -3,11 +3,13 @@ package section
 import (
 	"fmt"
 	"bytes"
+	"github.com/boiledgas/protocol/telematics/value"
 )
 
 type ModulePropertyValue struct {
 	ModuleId byte
 	Values   map[byte]interface{}
+	Types    map[byte]value.DataType // property data types, filled on read
 }
 
 func (s ModulePropertyValue) String() string {
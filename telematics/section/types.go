@@ -17,3 +17,33 @@ const (
 	SECTION_COMMAND_EXECUTE          Type = 0x09
 	SECTION_SUPPORTED                Type = 0x0A
 )
+
+func (t Type) String() string {
+	switch t {
+	case SECTION_UNKNOWN:
+		return "Unknown"
+	case SECTION_ENDOFPAYLOAD:
+		return "EndOfPayload"
+	case SECTION_IDENTIFICATION:
+		return "Identification"
+	case SECTION_AUTHENTICATION:
+		return "Authentication"
+	case SECTION_MODULE:
+		return "Module"
+	case SECTION_MODULE_PROPERTY:
+		return "ModuleProperty"
+	case SECTION_MODULE_PROPERTY_VALUE:
+		return "ModulePropertyValue"
+	case SECTION_MODULE_PROPERTY_DISABLED:
+		return "ModulePropertyDisabled"
+	case SECTION_COMMAND:
+		return "Command"
+	case SECTION_COMMAND_ARGUMENT:
+		return "CommandArgument"
+	case SECTION_COMMAND_EXECUTE:
+		return "CommandExecute"
+	case SECTION_SUPPORTED:
+		return "Supported"
+	}
+	return ""
+}
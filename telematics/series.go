@@ -0,0 +1,342 @@
+package telematics
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/boiledgas/protocol/telematics/section"
+	"github.com/boiledgas/protocol/telematics/value"
+)
+
+// Point is one property value of time series, composite values are expanded into fields
+type Point struct {
+	Device   string
+	Time     time.Time
+	Module   string
+	Property string
+	Type     value.DataType
+	Fields   map[string]interface{} // bool, int64, uint64, float64 or string
+}
+
+// Flattener turns property values of requests into points, names are taken from Configuration
+type Flattener struct {
+	Configuration *Configuration
+}
+
+// DeviceId returns text code of identification or numeric code if text is not set
+func DeviceId(id section.Identification) string {
+	if id.Has(section.IDENTIFICATION_FLAGS_CODETEXT) {
+		return id.CodeText
+	}
+	return strconv.FormatUint(uint64(id.Code), 10)
+}
+
+func (f *Flattener) Flatten(device string, r *Request) (points []Point) {
+	ts := time.Unix(int64(r.Timestamp), 0).UTC()
+	for _, s := range r.Values {
+		module := f.moduleName(s.ModuleId)
+		ids := make([]int, 0, len(s.Values))
+		for id := range s.Values {
+			ids = append(ids, int(id))
+		}
+		sort.Ints(ids)
+		for _, id := range ids {
+			v := s.Values[byte(id)]
+			name, t := f.property(s.ModuleId, byte(id))
+			if st, ok := s.Types[byte(id)]; ok {
+				t = st
+			}
+			if t == value.NotSet {
+				t = value.TypeOf(v)
+			}
+			fields := Fields(v)
+			if len(fields) == 0 {
+				continue
+			}
+			if f.Configuration != nil {
+				if label, ok := f.Configuration.Label(s.ModuleId, byte(id), v); ok {
+					fields["label"] = label
+				}
+			}
+			points = append(points, Point{
+				Device:   device,
+				Time:     ts,
+				Module:   module,
+				Property: name,
+				Type:     t,
+				Fields:   fields,
+			})
+		}
+	}
+	return
+}
+
+func (f *Flattener) moduleName(moduleId byte) string {
+	var m section.Module
+	if f.Configuration != nil && f.Configuration.GetModule(moduleId, &m) && m.Name != "" {
+		return m.Name
+	}
+	return fmt.Sprintf("module%v", moduleId)
+}
+
+func (f *Flattener) property(moduleId byte, propertyId byte) (string, value.DataType) {
+	var p section.ModuleProperty
+	if f.Configuration != nil && f.Configuration.GetProperty(moduleId, propertyId, &p) {
+		if p.Name != "" {
+			return p.Name, p.Type
+		}
+		return fmt.Sprintf("property%v", propertyId), p.Type
+	}
+	return fmt.Sprintf("property%v", propertyId), value.NotSet
+}
+
+// Fields expands value into named fields, scalar values are stored as "value"
+func Fields(v interface{}) map[string]interface{} {
+	fields := make(map[string]interface{})
+	switch v := v.(type) {
+	case bool:
+		fields["value"] = v
+	case int8:
+		fields["value"] = int64(v)
+	case int16:
+		fields["value"] = int64(v)
+	case int32:
+		fields["value"] = int64(v)
+	case int64:
+		fields["value"] = v
+	case byte:
+		fields["value"] = int64(v)
+	case uint16:
+		fields["value"] = int64(v)
+	case uint32:
+		fields["value"] = int64(v)
+	case uint64:
+		fields["value"] = v
+	case float32:
+		fields["value"] = float32To64(v)
+	case float64:
+		fields["value"] = v
+	case string:
+		fields["value"] = v
+	case []byte:
+		fields["value"] = hex.EncodeToString(v)
+	case time.Time:
+		fields["value"] = v.Unix()
+	case time.Duration:
+		fields["value"] = v.Seconds()
+	case value.Common:
+		commonFields(fields, v)
+	case value.IoPort:
+		fields["state"] = int64(v.State)
+		for _, p := range v.Pins() {
+			fields[fmt.Sprintf("pin%v", p.Number)] = p.High
+		}
+	case value.Gps:
+		gpsFields(fields, v)
+	case value.Gsm:
+		fields["mcc"] = v.MCC
+		fields["mnc"] = v.MNC
+		fields["lac"] = int64(v.LAC)
+		fields["cid"] = int64(v.CID)
+		fields["signal"] = int64(v.Signal)
+	case value.CellInfo:
+		fields["mcc"] = v.MCC
+		fields["mnc"] = v.MNC
+		fields["radio"] = v.Serving.Radio.String()
+		if v.Serving.Radio.HasTAC() {
+			fields["tac"] = int64(v.Serving.TAC)
+		} else {
+			fields["lac"] = int64(v.Serving.LAC)
+		}
+		fields["cid"] = int64(v.Serving.CID)
+		fields["signal"] = int64(v.Serving.Signal)
+		fields["neighbors"] = int64(len(v.Neighbors))
+	case value.Acceleration:
+		accelerationFields(fields, v)
+	case value.Rgb:
+		fields["r"] = int64(v.R)
+		fields["g"] = int64(v.G)
+		fields["b"] = int64(v.B)
+	}
+	return fields
+}
+
+func commonFields(fields map[string]interface{}, v value.Common) {
+	var flags [8]byte
+	v.Load(&flags)
+	for _, flag := range flags {
+		if flag == 0 {
+			continue
+		}
+		switch flag {
+		case value.COMMON_FLAG_STATE:
+			fields["state"] = v.State
+		case value.COMMON_FLAG_PERCENTAGE:
+			fields["percentage"] = int64(v.Percentage)
+		case value.COMMON_FLAG_VALUE:
+			fields["value"] = v.Value
+		case value.COMMON_FLAG_METER:
+			fields["meter"] = v.Meter
+		}
+	}
+}
+
+func gpsFields(fields map[string]interface{}, v value.Gps) {
+	var flags [8]byte
+	v.Load(&flags)
+	for _, flag := range flags {
+		if flag == 0 {
+			continue
+		}
+		switch flag {
+		case value.GPS_FLAG_LATLNG:
+			fields["latitude"] = v.Latitude
+			fields["longitude"] = v.Longitude
+		case value.GPS_FLAG_ALTITUDE:
+			fields["altitude"] = int64(v.Altitude)
+		case value.GPS_FLAG_SPEED:
+			fields["speed"] = float64(v.Speed)
+		case value.GPS_FLAG_COURSE:
+			fields["course"] = float64(v.Course)
+		case value.GPS_FLAG_SATELLITES:
+			fields["satellites"] = int64(v.Sat)
+		case value.GPS_FLAG_DOP:
+			fields["hdop"] = float32To64(v.Hdop)
+			fields["pdop"] = float32To64(v.Pdop)
+		case value.GPS_FLAG_FIX:
+			fields["fix"] = v.Fix.String()
+			fields["valid"] = v.Valid
+			fields["constellation"] = int64(v.Constellation)
+		case value.GPS_FLAG_EXTENDED:
+			// precise values replace byte speed and course
+			if v.Extended.Has(value.GPS_EXTFLAG_SPEED) {
+				fields["speed"] = float32To64(v.PreciseSpeed)
+			}
+			if v.Extended.Has(value.GPS_EXTFLAG_COURSE) {
+				fields["course"] = float32To64(v.PreciseCourse)
+			}
+		}
+	}
+}
+
+func accelerationFields(fields map[string]interface{}, v value.Acceleration) {
+	var flags [8]byte
+	v.Load(&flags)
+	for _, flag := range flags {
+		if flag == 0 {
+			continue
+		}
+		switch flag {
+		case value.ACCELERATION_FLAG_X:
+			fields["x"] = float32To64(v.AxisX)
+		case value.ACCELERATION_FLAG_Y:
+			fields["y"] = float32To64(v.AxisY)
+		case value.ACCELERATION_FLAG_Z:
+			fields["z"] = float32To64(v.AxisZ)
+		case value.ACCELERATION_FLAG_DURATION:
+			fields["duration"] = int64(v.Duration)
+		case value.ACCELERATION_FLAG_GYRO_X:
+			fields["gyro_x"] = float32To64(v.GyroX)
+		case value.ACCELERATION_FLAG_GYRO_Y:
+			fields["gyro_y"] = float32To64(v.GyroY)
+		case value.ACCELERATION_FLAG_GYRO_Z:
+			fields["gyro_z"] = float32To64(v.GyroZ)
+		}
+	}
+	if v.Has(value.ACCELERATION_FLAG_X) || v.Has(value.ACCELERATION_FLAG_Y) || v.Has(value.ACCELERATION_FLAG_Z) {
+		fields["g_force"] = v.GForce()
+	}
+}
+
+// float32To64 keeps shortest decimal representation, 0.1 stays 0.1 instead of 0.10000000149
+func float32To64(v float32) float64 {
+	res, _ := strconv.ParseFloat(strconv.FormatFloat(float64(v), 'g', -1, 32), 64)
+	return res
+}
+
+// LineProtocol formats point in InfluxDB line protocol, module is measurement.
+// Empty tags and NaN or infinite fields are skipped, point without fields is formatted as empty string
+func (p Point) LineProtocol() string {
+	keys := make([]string, 0, len(p.Fields))
+	for k, v := range p.Fields {
+		if f, ok := v.(float64); ok && (math.IsNaN(f) || math.IsInf(f, 0)) {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	if len(keys) == 0 {
+		return ""
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	buf.WriteString(lineEscaper.Replace(p.Module))
+	for _, tag := range [][2]string{{"device", p.Device}, {"property", p.Property}, {"type", p.Type.String()}} {
+		if tag[1] == "" {
+			continue
+		}
+		buf.WriteByte(',')
+		buf.WriteString(tag[0])
+		buf.WriteByte('=')
+		buf.WriteString(tagEscaper.Replace(tag[1]))
+	}
+	buf.WriteByte(' ')
+
+	for i, k := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(tagEscaper.Replace(k))
+		buf.WriteByte('=')
+		switch v := p.Fields[k].(type) {
+		case bool:
+			buf.WriteString(strconv.FormatBool(v))
+		case int64:
+			buf.WriteString(strconv.FormatInt(v, 10))
+			buf.WriteByte('i')
+		case uint64:
+			buf.WriteString(strconv.FormatUint(v, 10))
+			buf.WriteByte('u')
+		case float64:
+			buf.WriteString(strconv.FormatFloat(v, 'g', -1, 64))
+		case string:
+			buf.WriteByte('"')
+			buf.WriteString(stringEscaper.Replace(v))
+			buf.WriteByte('"')
+		default:
+			buf.WriteByte('"')
+			buf.WriteString(stringEscaper.Replace(fmt.Sprint(v)))
+			buf.WriteByte('"')
+		}
+	}
+	buf.WriteByte(' ')
+	buf.WriteString(strconv.FormatInt(p.Time.UnixNano(), 10))
+	return buf.String()
+}
+
+var (
+	lineEscaper   = strings.NewReplacer(",", `\,`, " ", `\ `, "\n", `\n`)
+	tagEscaper    = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `, "\n", `\n`)
+	stringEscaper = strings.NewReplacer(`"`, `\"`, `\`, `\\`)
+)
+
+// WriteLineProtocol writes points one per line, points without fields are skipped
+func WriteLineProtocol(w io.Writer, points []Point) error {
+	for _, p := range points {
+		line := p.LineProtocol()
+		if line == "" {
+			continue
+		}
+		if _, err := io.WriteString(w, line+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
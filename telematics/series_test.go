@@ -0,0 +1,88 @@
+package telematics
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boiledgas/protocol/telematics/section"
+	"github.com/boiledgas/protocol/telematics/value"
+)
+
+func Test_Flatten(t *testing.T) {
+	conf := testConfiguration()
+	gps := value.Gps{Latitude: 55.75, Longitude: 37.61, Sat: 9}
+	gps.Set(value.GPS_FLAG_LATLNG, true)
+	gps.Set(value.GPS_FLAG_SATELLITES, true)
+	req := Request{Timestamp: 1500000000}
+	req.Values = []section.ModulePropertyValue{{
+		ModuleId: 1,
+		Values:   map[byte]interface{}{1: gps, 2: float32(21.1), 9: int32(-5)},
+	}}
+	req.Set(section.FLAG_MODULE_PROPERTY_VALUE, true)
+
+	f := Flattener{Configuration: &conf}
+	points := f.Flatten("dev 1", &req)
+	if len(points) != 3 {
+		t.Fatalf("points count wrong: %v", points)
+	}
+	if points[0].Property != "position" || points[0].Type != value.GPS || points[0].Fields["latitude"] != 55.75 || points[0].Fields["satellites"] != int64(9) {
+		t.Errorf("gps point wrong: %v", points[0])
+	}
+	if _, ok := points[0].Fields["altitude"]; ok {
+		t.Error("altitude is not flagged")
+	}
+	if points[1].Type != value.Temperature || points[1].Fields["value"] != 21.1 {
+		t.Errorf("temperature point wrong: %v", points[1])
+	}
+	if points[2].Property != "property9" || points[2].Type != value.Int {
+		t.Errorf("unknown property point wrong: %v", points[2])
+	}
+
+	buf := bytes.Buffer{}
+	if err := WriteLineProtocol(&buf, points); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	expected := []string{
+		`sensors,device=dev\ 1,property=position,type=GPS latitude=55.75,longitude=37.61,satellites=9i 1500000000000000000`,
+		`sensors,device=dev\ 1,property=property2,type=Temperature value=21.1 1500000000000000000`,
+		`sensors,device=dev\ 1,property=property9,type=Int value=-5i 1500000000000000000`,
+	}
+	for i, l := range lines {
+		if i < len(expected) && l != expected[i] {
+			t.Errorf("line wrong: %v != %v", l, expected[i])
+		}
+	}
+}
+
+func Test_FieldsComposite(t *testing.T) {
+	c := value.Common{State: true, Meter: 12.5}
+	c.Set(value.COMMON_FLAG_STATE, true)
+	c.Set(value.COMMON_FLAG_METER, true)
+	fields := Fields(c)
+	if len(fields) != 2 || fields["state"] != true || fields["meter"] != 12.5 {
+		t.Errorf("common fields wrong: %v", fields)
+	}
+
+	p := Point{Module: "m", Device: "d", Property: "p", Type: value.String, Fields: Fields(`say "hi"`)}
+	if l := p.LineProtocol(); !strings.Contains(l, `value="say \"hi\""`) {
+		t.Errorf("string field wrong: %v", l)
+	}
+}
+
+func Test_LineProtocolInvalid(t *testing.T) {
+	ts := time.Unix(1500000000, 0)
+	p := Point{Module: "sensors", Property: "temp", Type: value.DataType(0xee), Time: ts,
+		Fields: map[string]interface{}{"value": math.NaN(), "max": math.Inf(1), "min": -1.5, "note": "a\"b\\"}}
+	if line := p.LineProtocol(); line != `sensors,property=temp min=-1.5,note="a\"b\\" 1500000000000000000` {
+		t.Errorf("line wrong: %v", line)
+	}
+	p.Fields = map[string]interface{}{"value": math.NaN()}
+	buf := bytes.Buffer{}
+	if err := WriteLineProtocol(&buf, []Point{p}); err != nil || buf.Len() != 0 {
+		t.Errorf("point without fields written: %q", buf.String())
+	}
+}
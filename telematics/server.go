@@ -0,0 +1,353 @@
+package telematics
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/boiledgas/protocol/telematics/section"
+)
+
+// Handler processes requests of session, response sequence is set by server
+type Handler interface {
+	Handle(s *Session, r *Request) Response
+}
+
+type HandlerFunc func(s *Session, r *Request) Response
+
+func (f HandlerFunc) Handle(s *Session, r *Request) Response {
+	return f(s, r)
+}
+
+// Server reads packets of device connections and answers requests
+type Server struct {
+	Authenticator Authenticator // values and commands are rejected until session is authenticated, nil disables authentication
+	Handler       Handler
+	Middleware    []Middleware // wrap processing of authenticated requests, first middleware is outermost
+	Tracker       *Tracker     // receives responses and command results, queued commands are sent to authenticated sessions
+	State         *DeviceState // updated with requests of authenticated sessions
+	ErrorLog      *log.Logger
+	Metrics       Metrics // receives measurements of packets, sessions and requests, nil disables instrumentation
+
+	TLSConfig           *tls.Config                                  // connections of Serve and ListenAndServe use TLS if set
+	CertificateIdentity func(cert *x509.Certificate) (string, error) // maps verified client certificate to device, common name if nil
+
+	mutex    sync.Mutex
+	sessions map[*Session]bool
+}
+
+func (srv *Server) register(s *Session) {
+	srv.mutex.Lock()
+	if srv.sessions == nil {
+		srv.sessions = make(map[*Session]bool)
+	}
+	registered := srv.sessions[s]
+	srv.sessions[s] = true
+	srv.mutex.Unlock()
+	if srv.Metrics != nil && !registered {
+		s.metrics = srv.Metrics
+		srv.Metrics.Add(METRIC_SESSIONS, 1)
+	}
+}
+
+// unregister removes session, device is disconnected in state if it has no other session
+func (srv *Server) unregister(s *Session) {
+	srv.mutex.Lock()
+	registered := srv.sessions[s]
+	delete(srv.sessions, s)
+	srv.mutex.Unlock()
+	s.close()
+	if srv.Metrics != nil && registered {
+		srv.Metrics.Add(METRIC_SESSIONS, -1)
+	}
+	device := s.State().Device
+	if srv.State == nil || device == "" {
+		return
+	}
+	if _, ok := srv.Session(device); !ok {
+		srv.State.Disconnect(device)
+	}
+}
+
+// Sessions returns served sessions ordered by device
+func (srv *Server) Sessions() []*Session {
+	srv.mutex.Lock()
+	sessions := make([]*Session, 0, len(srv.sessions))
+	for s := range srv.sessions {
+		sessions = append(sessions, s)
+	}
+	srv.mutex.Unlock()
+	states := make(map[*Session]SessionState, len(sessions))
+	for _, s := range sessions {
+		states[s] = s.State()
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		a, b := states[sessions[i]], states[sessions[j]]
+		if a.Device != b.Device {
+			return a.Device < b.Device
+		}
+		return a.Seen.Before(b.Seen)
+	})
+	return sessions
+}
+
+// Session returns last active session of device
+func (srv *Server) Session(device string) (*Session, bool) {
+	var res *Session
+	var seen time.Time
+	for _, s := range srv.Sessions() {
+		state := s.State()
+		if state.Device == device && (res == nil || !state.Seen.Before(seen)) {
+			res, seen = s, state.Seen
+		}
+	}
+	return res, res != nil
+}
+
+// Configuration returns configuration of device session, last configuration kept in State
+// if session has none, false if device is unknown
+func (srv *Server) Configuration(device string) (conf Configuration, ok bool) {
+	var s *Session
+	if s, ok = srv.Session(device); ok {
+		if conf = s.State().Configuration; len(conf.Modules) > 0 {
+			return
+		}
+	}
+	if srv.State != nil {
+		if snapshot, known := srv.State.Get(device); known && len(snapshot.Configuration.Modules) > 0 {
+			return snapshot.Configuration, true
+		}
+	}
+	return
+}
+
+func (srv *Server) logf(format string, args ...interface{}) {
+	if srv.ErrorLog != nil {
+		srv.ErrorLog.Printf(format, args...)
+	} else {
+		log.Printf(format, args...)
+	}
+}
+
+// ListenAndServe listens on TCP address and serves connections
+func (srv *Server) ListenAndServe(addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+	return srv.Serve(l)
+}
+
+// Serve accepts connections of listener and serves each in own goroutine
+func (srv *Server) Serve(l net.Listener) error {
+	if srv.TLSConfig != nil {
+		l = tls.NewListener(l, srv.TLSConfig)
+	}
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go func() {
+			defer conn.Close()
+			if err := srv.ServeConn(conn); err != nil {
+				srv.logf("telematics: %v: %v", conn.RemoteAddr(), err)
+			}
+		}()
+	}
+}
+
+// ServeConn serves packets of connection until it is closed or packet can't be read
+func (srv *Server) ServeConn(conn io.ReadWriter) error {
+	s := NewSession(conn)
+	if c, ok := conn.(*tls.Conn); ok {
+		if err := srv.handshake(s, c); err != nil {
+			return err
+		}
+	}
+	srv.register(s)
+	defer srv.unregister(s)
+	reader := NewReader(conn)
+	reader.Configuration = &s.Configuration
+	reader.Metrics = srv.Metrics
+	for {
+		p := Packet{}
+		if err := readPacket(reader, &p); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if err := srv.ServePacket(s, &p); err != nil {
+			return err
+		}
+	}
+}
+
+// ServePacket answers request of session and delivers queued commands, passes response
+// to exchange of session waiting for it or to tracker. Commands which can't be delivered are logged
+// and stay queued
+func (srv *Server) ServePacket(s *Session, p *Packet) error {
+	switch {
+	case p.Has(FLAG_REQUEST):
+		resp := srv.Process(s, &p.Request)
+		if err := s.Respond(&resp); err != nil {
+			return err
+		}
+		if err := srv.Deliver(s); err != nil {
+			srv.logf("telematics: %v: deliver: %v", deviceName(s), err)
+		}
+	case p.Has(FLAG_RESPONSE):
+		if s.receive(p.Response) {
+			return nil
+		}
+		if device := s.State().Device; srv.Tracker != nil && device != "" {
+			if err := srv.Tracker.Response(device, p.Response); err != nil {
+				srv.logf("telematics: %v: %v", device, err)
+			}
+		}
+	}
+	return nil
+}
+
+func readPacket(reader *TelematicsReader, p *Packet) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("read packet: %v", rec)
+		}
+	}()
+	return reader.Read(p)
+}
+
+// Process updates session with identification and configuration of request and authenticates it,
+// then passes request through middlewares to processing of server and returns response,
+// middlewares see authenticated session and get authorization error as response if authentication fails,
+// panic of authenticator, middleware or handler is logged and answered with error
+func (srv *Server) Process(s *Session, r *Request) Response {
+	start := time.Now()
+	s.update(r, srv.Authenticator == nil && s.Peer == "", start)
+	resp := srv.handle(s, r)
+	resp.Sequence = r.Sequence
+	srv.challenge(s, &resp)
+	if srv.Metrics != nil {
+		srv.Metrics.Observe(METRIC_REQUEST_DURATION, time.Since(start).Seconds())
+	}
+	return resp
+}
+
+// handle authenticates session and passes request through middlewares, authentication failure
+// and values or commands of not authenticated session are answered with authorization error
+func (srv *Server) handle(s *Session, r *Request) (resp Response) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			srv.logf("telematics: %v: panic in request %v: %v\n%s", deviceName(s), r.Sequence, rec, debug.Stack())
+			resp = Response{Flags: RESPONSE_ERROR}
+		}
+	}()
+	var h Handler = HandlerFunc(srv.process)
+	if err := srv.authenticate(s, r); err != nil {
+		srv.logf("telematics: %v", err)
+		h = HandlerFunc(unauthorized)
+	}
+	return Chain(h, srv.Middleware...).Handle(s, r)
+}
+
+func unauthorized(s *Session, r *Request) Response {
+	return Response{Flags: RESPONSE_AUTHORIZATION | RESPONSE_ERROR}
+}
+
+// process drops values of disabled properties of authenticated session, stores values in State and calls handler
+func (srv *Server) process(s *Session, r *Request) Response {
+	if dropped := s.Disabled.Filter(r); dropped > 0 {
+		srv.logf("telematics: %v: %v values of disabled properties dropped", s.Device, dropped)
+	}
+	if srv.State != nil && s.Device != "" {
+		srv.State.Update(s.Device, r)
+	}
+	if srv.Tracker != nil && s.Device != "" {
+		if err := srv.Tracker.Results(s.Device, r); err != nil {
+			srv.logf("telematics: %v: %v", s.Device, err)
+		}
+	}
+	if srv.Handler == nil {
+		return Response{Flags: RESPONSE_OK}
+	}
+	return srv.Handler.Handle(s, r)
+}
+
+// challenge adds new nonce to response if session is not authenticated by challenger
+func (srv *Server) challenge(s *Session, resp *Response) {
+	c, ok := srv.Authenticator.(Challenger)
+	if !ok || s.authenticated {
+		return
+	}
+	nonce, err := c.Challenge(s)
+	if err != nil {
+		srv.logf("telematics: challenge: %v", err)
+		return
+	}
+	resp.Flags |= RESPONSE_CHALLENGE
+	resp.Nonce = nonce
+}
+
+// authenticate calls authenticator on first authentication section of session
+func (srv *Server) authenticate(s *Session, r *Request) error {
+	if srv.Authenticator == nil && !s.authenticated {
+		s.setAuthenticated(s.Device, true)
+		srv.connect(s)
+	}
+	if s.authenticated {
+		return nil
+	}
+	if r.Has(section.FLAG_AUTHENTICATION) {
+		device, err := srv.Authenticator.Authenticate(s, r)
+		if err != nil {
+			return err
+		}
+		s.setAuthenticated(device, true)
+		srv.connect(s)
+		return nil
+	}
+	if r.Has(section.FLAG_MODULE_PROPERTY_VALUE) || r.Has(section.FLAG_COMMAND_EXECUTE) {
+		return ErrNotAuthenticated
+	}
+	return nil
+}
+
+// connect marks device of authenticated session connected in State
+func (srv *Server) connect(s *Session) {
+	if device := s.State().Device; srv.State != nil && device != "" {
+		srv.State.Connect(device)
+	}
+}
+
+// Deliver sends commands queued for device of authenticated session, arguments are written
+// with configuration of device, see Configuration
+func (srv *Server) Deliver(s *Session) error {
+	if srv.Tracker == nil {
+		return nil
+	}
+	state := s.State()
+	if state.Device == "" || !state.Authenticated {
+		return nil
+	}
+	send := s.Send
+	if conf, ok := srv.Configuration(state.Device); ok {
+		send = func(r *Request) error {
+			return s.write(func(w *TelematicsWriter) error {
+				w.Configuration = &conf
+				return w.WriteRequest(r)
+			})
+		}
+	}
+	_, err := srv.Tracker.Deliver(state.Device, send)
+	return err
+}
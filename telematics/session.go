@@ -0,0 +1,205 @@
+package telematics
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/boiledgas/protocol/telematics/section"
+)
+
+var ErrSessionClosed = errors.New("session closed")
+
+// Session is state of one device connection, fields are updated by server from requests of device,
+// other goroutines read them by State
+type Session struct {
+	Device         string // identity returned by authenticator or identification code
+	Identification section.Identification
+	Configuration  Configuration
+	Nonce          []byte // last challenge sent to device
+	TLS            *tls.ConnectionState
+	Peer           string             // device of verified client certificate, session is authenticated by certificate
+	Disabled       DisabledProperties // properties disabled by device, their values are dropped
+
+	authenticated bool
+	seen          time.Time
+	pending       map[byte]chan Response // exchanges waiting for response by sequence
+	closed        bool
+	conn          io.Writer
+	metrics       Metrics      // set by server
+	mutex         sync.Mutex   // serializes writes, each packet is written by one Write
+	state         sync.RWMutex // guards fields updated by server
+}
+
+// SessionState is copy of session fields
+type SessionState struct {
+	Device         string
+	Authenticated  bool
+	Identification section.Identification
+	Configuration  Configuration
+	Seen           time.Time // time of last request
+}
+
+func NewSession(w io.Writer) *Session {
+	return &Session{conn: w}
+}
+
+// Authenticated returns true after successful authentication, always true if server requires none
+func (s *Session) Authenticated() bool {
+	s.state.RLock()
+	defer s.state.RUnlock()
+	return s.authenticated
+}
+
+func (s *Session) State() SessionState {
+	s.state.RLock()
+	defer s.state.RUnlock()
+	return SessionState{
+		Device:         s.Device,
+		Authenticated:  s.authenticated,
+		Identification: s.Identification,
+		Configuration:  s.Configuration,
+		Seen:           s.seen,
+	}
+}
+
+// update stores identification, configuration and disabled properties of request
+func (s *Session) update(r *Request, identify bool, now time.Time) {
+	s.state.Lock()
+	defer s.state.Unlock()
+	s.seen = now
+	if r.Has(section.FLAG_IDENTIFICATION) {
+		s.Identification = r.Id
+		if identify {
+			s.Device = DeviceId(r.Id)
+		}
+	}
+	if r.HasConfiguration() {
+		s.Configuration = r.Conf
+		s.Disabled.Init(&s.Configuration)
+	}
+	if r.Has(section.FLAG_MODULE_PROPERTY_DISABLED) {
+		s.Disabled.Load(r.Disabled)
+	}
+}
+
+func (s *Session) setAuthenticated(device string, authenticated bool) {
+	s.state.Lock()
+	s.Device, s.authenticated = device, authenticated
+	s.state.Unlock()
+}
+
+// Send writes request to device
+func (s *Session) Send(r *Request) error {
+	return s.write(func(w *TelematicsWriter) error {
+		return w.WriteRequest(r)
+	})
+}
+
+// Respond writes response to device
+func (s *Session) Respond(resp *Response) error {
+	return s.write(func(w *TelematicsWriter) error {
+		return w.WriteResponse(resp)
+	})
+}
+
+// Exchange writes request to device and waits for response with its sequence, response is
+// read by server serving the session, so it must not be called by handler of the session.
+// Zero timeout waits until session is closed
+func (s *Session) Exchange(r *Request, timeout time.Duration) (resp Response, err error) {
+	ch := make(chan Response, 1)
+	s.state.Lock()
+	if s.closed {
+		s.state.Unlock()
+		return resp, ErrSessionClosed
+	}
+	if s.pending == nil {
+		s.pending = make(map[byte]chan Response)
+	}
+	s.pending[r.Sequence] = ch
+	s.state.Unlock()
+	defer func() {
+		s.state.Lock()
+		if s.pending[r.Sequence] == ch {
+			delete(s.pending, r.Sequence)
+		}
+		s.state.Unlock()
+	}()
+
+	if err = s.Send(r); err != nil {
+		return
+	}
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+	select {
+	case resp, ok := <-ch:
+		if !ok {
+			return resp, ErrSessionClosed
+		}
+		return resp, nil
+	case <-expired:
+		return resp, ErrResponseTimeout
+	}
+}
+
+// receive passes response to exchange waiting for it, false if no exchange waits
+func (s *Session) receive(resp Response) bool {
+	s.state.Lock()
+	defer s.state.Unlock()
+	ch, ok := s.pending[resp.Sequence]
+	if ok {
+		delete(s.pending, resp.Sequence)
+		ch <- resp
+	}
+	return ok
+}
+
+// close fails waiting exchanges
+func (s *Session) close() {
+	s.state.Lock()
+	s.closed = true
+	for sequence, ch := range s.pending {
+		close(ch)
+		delete(s.pending, sequence)
+	}
+	s.state.Unlock()
+}
+
+// write sends packets written by f with one write of connection, packets are counted
+// in metrics of session after connection accepts them
+func (s *Session) write(f func(w *TelematicsWriter) error) (err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("session %v: %v", s.Device, rec)
+		}
+	}()
+	var buf bytes.Buffer
+	var pending pendingMetrics
+	w := NewWriter(&buf)
+	w.Configuration = &s.Configuration
+	if s.metrics != nil {
+		w.Metrics = &pending
+	}
+	err = func() error {
+		s.state.RLock()
+		defer s.state.RUnlock()
+		return f(w)
+	}()
+	if err != nil || buf.Len() == 0 {
+		return
+	}
+	if _, err = s.conn.Write(buf.Bytes()); err == nil && s.metrics != nil {
+		pending.flush(s.metrics)
+	}
+	return
+}
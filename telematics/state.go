@@ -0,0 +1,256 @@
+package telematics
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/boiledgas/protocol/telematics/section"
+	"github.com/boiledgas/protocol/telematics/value"
+)
+
+// DeviceState keeps last known identification, configuration, connection status and property values of devices,
+// subscribers are notified when value of property changes, changes are notified in order of updates
+type DeviceState struct {
+	mutex       sync.RWMutex
+	devices     map[string]*deviceRecord
+	subscribers map[int]func(ValueChange)
+	next        int
+	queue       []ValueChange // changes not notified yet
+	delivering  bool          // goroutine notifies queued changes
+
+	now func() time.Time
+}
+
+type deviceRecord struct {
+	identification section.Identification
+	configuration  Configuration
+	connected      bool
+	seen           time.Time
+	values         map[section.PropertyRef]PropertyValue
+}
+
+// DeviceSnapshot is copy of device state
+type DeviceSnapshot struct {
+	Device         string
+	Identification section.Identification
+	Configuration  Configuration
+	Connected      bool
+	Seen           time.Time
+	Values         []PropertyValue // ordered by module and property id
+}
+
+// PropertyValue is last value of property reported by device
+type PropertyValue struct {
+	section.PropertyRef
+	Type  value.DataType
+	Value interface{}
+	Time  time.Time // timestamp of request
+}
+
+// ValueChange is new value of property, Old is not set for first value
+type ValueChange struct {
+	Device string
+	Old    *PropertyValue
+	New    PropertyValue
+}
+
+func NewDeviceState() *DeviceState {
+	return &DeviceState{devices: make(map[string]*deviceRecord), subscribers: make(map[int]func(ValueChange)), now: time.Now}
+}
+
+func (d *DeviceState) clock() time.Time {
+	if d.now == nil {
+		return time.Now()
+	}
+	return d.now()
+}
+
+func (d *DeviceState) record(device string) *deviceRecord {
+	if d.devices == nil {
+		d.devices = make(map[string]*deviceRecord)
+	}
+	rec, ok := d.devices[device]
+	if !ok {
+		rec = &deviceRecord{values: make(map[section.PropertyRef]PropertyValue)}
+		d.devices[device] = rec
+	}
+	return rec
+}
+
+// Update stores identification, configuration and values of request, device is marked connected and seen.
+// Values older than stored value are ignored, subscribers receive changed values
+func (d *DeviceState) Update(device string, r *Request) {
+	now := d.clock()
+	ts := now
+	if r.Timestamp != 0 {
+		ts = time.Unix(int64(r.Timestamp), 0).UTC()
+	}
+	var changes []ValueChange
+	d.mutex.Lock()
+	rec := d.record(device)
+	rec.connected, rec.seen = true, now
+	if r.Has(section.FLAG_IDENTIFICATION) {
+		rec.identification = r.Id
+	}
+	if r.HasConfiguration() {
+		rec.configuration = r.Conf
+	}
+	for _, s := range r.Values {
+		for id, v := range s.Values {
+			ref := section.PropertyRef{ModuleId: s.ModuleId, PropertyId: id}
+			t, ok := s.Types[id]
+			if !ok {
+				t = value.TypeOf(v)
+			}
+			pv := PropertyValue{PropertyRef: ref, Type: t, Value: v, Time: ts}
+			old, ok := rec.values[ref]
+			if ok && ts.Before(old.Time) {
+				continue
+			}
+			rec.values[ref] = pv
+			if !ok {
+				changes = append(changes, ValueChange{Device: device, New: pv})
+			} else if !reflect.DeepEqual(old.Value, v) {
+				changes = append(changes, ValueChange{Device: device, Old: &old, New: pv})
+			}
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		a, b := changes[i].New, changes[j].New
+		if a.ModuleId != b.ModuleId {
+			return a.ModuleId < b.ModuleId
+		}
+		return a.PropertyId < b.PropertyId
+	})
+	d.queue = append(d.queue, changes...)
+	if d.delivering || len(d.queue) == 0 {
+		d.mutex.Unlock()
+		return
+	}
+	d.delivering = true
+	d.mutex.Unlock()
+	d.deliver()
+}
+
+// deliver notifies subscribers of queued changes until queue is empty, changes queued
+// by concurrent updates are notified by delivering goroutine
+func (d *DeviceState) deliver() {
+	d.mutex.Lock()
+	for len(d.queue) > 0 {
+		changes := d.queue
+		d.queue = nil
+		subscribers := d.subscriberList()
+		d.mutex.Unlock()
+		for _, c := range changes {
+			for _, f := range subscribers {
+				f(c)
+			}
+		}
+		d.mutex.Lock()
+	}
+	d.delivering = false
+	d.mutex.Unlock()
+}
+
+// subscriberList returns subscribers in subscription order, mutex must be held
+func (d *DeviceState) subscriberList() []func(ValueChange) {
+	ids := make([]int, 0, len(d.subscribers))
+	for id := range d.subscribers {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	res := make([]func(ValueChange), 0, len(ids))
+	for _, id := range ids {
+		res = append(res, d.subscribers[id])
+	}
+	return res
+}
+
+// Connect marks device connected
+func (d *DeviceState) Connect(device string) {
+	d.mutex.Lock()
+	rec := d.record(device)
+	rec.connected, rec.seen = true, d.clock()
+	d.mutex.Unlock()
+}
+
+// Disconnect marks device disconnected, values are kept
+func (d *DeviceState) Disconnect(device string) {
+	d.mutex.Lock()
+	if rec, ok := d.devices[device]; ok {
+		rec.connected = false
+	}
+	d.mutex.Unlock()
+}
+
+func (d *DeviceState) Get(device string) (DeviceSnapshot, bool) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	rec, ok := d.devices[device]
+	if !ok {
+		return DeviceSnapshot{}, false
+	}
+	snapshot := DeviceSnapshot{
+		Device:         device,
+		Identification: rec.identification,
+		Configuration:  rec.configuration,
+		Connected:      rec.connected,
+		Seen:           rec.seen,
+		Values:         make([]PropertyValue, 0, len(rec.values)),
+	}
+	for _, v := range rec.values {
+		snapshot.Values = append(snapshot.Values, v)
+	}
+	sort.Slice(snapshot.Values, func(i, j int) bool {
+		a, b := snapshot.Values[i], snapshot.Values[j]
+		if a.ModuleId != b.ModuleId {
+			return a.ModuleId < b.ModuleId
+		}
+		return a.PropertyId < b.PropertyId
+	})
+	return snapshot, true
+}
+
+// Value returns last value of device property
+func (d *DeviceState) Value(device string, moduleId byte, propertyId byte) (PropertyValue, bool) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	rec, ok := d.devices[device]
+	if !ok {
+		return PropertyValue{}, false
+	}
+	v, ok := rec.values[section.PropertyRef{ModuleId: moduleId, PropertyId: propertyId}]
+	return v, ok
+}
+
+// Devices returns known devices ordered by name
+func (d *DeviceState) Devices() []string {
+	d.mutex.RLock()
+	res := make([]string, 0, len(d.devices))
+	for device := range d.devices {
+		res = append(res, device)
+	}
+	d.mutex.RUnlock()
+	sort.Strings(res)
+	return res
+}
+
+// Subscribe calls f for each changed value until returned cancel is called, f is called
+// by one of goroutines updating state and must not block
+func (d *DeviceState) Subscribe(f func(ValueChange)) (cancel func()) {
+	d.mutex.Lock()
+	if d.subscribers == nil {
+		d.subscribers = make(map[int]func(ValueChange))
+	}
+	d.next++
+	id := d.next
+	d.subscribers[id] = f
+	d.mutex.Unlock()
+	return func() {
+		d.mutex.Lock()
+		delete(d.subscribers, id)
+		d.mutex.Unlock()
+	}
+}
@@ -0,0 +1,109 @@
+package telematics
+
+import (
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/boiledgas/protocol/telematics/section"
+)
+
+func valuesRequest(timestamp int32, values map[byte]interface{}) *Request {
+	r := Request{Sequence: Sequence(), Timestamp: timestamp, Values: []section.ModulePropertyValue{{ModuleId: 3, Values: values}}}
+	r.Set(section.FLAG_MODULE_PROPERTY_VALUE, true)
+	return &r
+}
+
+func Test_DeviceState(t *testing.T) {
+	state := NewDeviceState()
+	var changes []ValueChange
+	cancel := state.Subscribe(func(c ValueChange) { changes = append(changes, c) })
+
+	state.Update("dev1", valuesRequest(100, map[byte]interface{}{1: true, 2: int32(5)}))
+	if len(changes) != 2 || changes[0].Old != nil || changes[0].New.PropertyId != 1 {
+		t.Fatalf("first values not notified: %v", changes)
+	}
+	changes = nil
+	state.Update("dev1", valuesRequest(110, map[byte]interface{}{1: true, 2: int32(6)}))
+	if len(changes) != 1 || changes[0].Old.Value != int32(5) || changes[0].New.Value != int32(6) {
+		t.Errorf("changed value wrong: %v", changes)
+	}
+	changes = nil
+	state.Update("dev1", valuesRequest(105, map[byte]interface{}{2: int32(7)}))
+	if v, _ := state.Value("dev1", 3, 2); v.Value != int32(6) || len(changes) != 0 {
+		t.Errorf("older value stored: %v %v", v, changes)
+	}
+	if v, _ := state.Value("dev1", 3, 1); v.Time != time.Unix(110, 0).UTC() {
+		t.Errorf("unchanged value time not updated: %v", v.Time)
+	}
+
+	cancel()
+	state.Update("dev1", valuesRequest(120, map[byte]interface{}{2: int32(8)}))
+	if len(changes) != 0 {
+		t.Errorf("cancelled subscriber notified: %v", changes)
+	}
+	snapshot, ok := state.Get("dev1")
+	if !ok || !snapshot.Connected || len(snapshot.Values) != 2 || snapshot.Values[1].Value != int32(8) {
+		t.Errorf("snapshot wrong: %v", snapshot)
+	}
+	state.Disconnect("dev1")
+	if snapshot, _ = state.Get("dev1"); snapshot.Connected || len(snapshot.Values) != 2 {
+		t.Errorf("disconnected snapshot wrong: %v", snapshot)
+	}
+}
+
+func Test_DeviceStateNotifyOrder(t *testing.T) {
+	state := NewDeviceState()
+	var last interface{}
+	var broken int
+	state.Subscribe(func(c ValueChange) {
+		if c.Old != nil && c.Old.Value != last {
+			broken++
+		}
+		last = c.New.Value
+	})
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				state.Update("dev1", valuesRequest(100, map[byte]interface{}{1: int32(g*1000 + i)}))
+			}
+		}(g)
+	}
+	wg.Wait()
+	if v, _ := state.Value("dev1", 3, 1); broken != 0 || v.Value != last {
+		t.Errorf("changes notified out of order: %v broken, last %v, stored %v", broken, last, v.Value)
+	}
+}
+
+func Test_ServerDeviceState(t *testing.T) {
+	srv := Server{State: NewDeviceState()}
+	device, conn := net.Pipe()
+	done := make(chan error)
+	go func() { done <- srv.ServeConn(conn) }()
+
+	r := valuesRequest(0, nil)
+	r.Id = section.Identification{Code: 42}
+	r.Id.Set(section.IDENTIFICATION_FLAGS_CODE, true)
+	r.Set(section.FLAG_IDENTIFICATION, true)
+	r.Set(section.FLAG_MODULE_PROPERTY_VALUE, false)
+	if err := NewWriter(device).WriteRequest(r); err != nil {
+		t.Fatal(err)
+	}
+	p := Packet{}
+	if err := NewReader(device).Read(&p); err != nil {
+		t.Fatal(err)
+	}
+	if snapshot, ok := srv.State.Get("42"); !ok || !snapshot.Connected || snapshot.Identification.Code != 42 {
+		t.Errorf("connected state wrong: %v", snapshot)
+	}
+	device.Close()
+	<-done
+	if snapshot, _ := srv.State.Get("42"); snapshot.Connected {
+		t.Error("device connected after session end")
+	}
+}
@@ -0,0 +1,80 @@
+package telematics
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+)
+
+// handshake completes TLS handshake of connection, session with verified client certificate is authenticated
+func (srv *Server) handshake(s *Session, conn *tls.Conn) error {
+	if err := conn.Handshake(); err != nil {
+		return err
+	}
+	state := conn.ConnectionState()
+	s.TLS = &state
+	if len(state.VerifiedChains) == 0 {
+		return nil
+	}
+	identity := CommonNameIdentity
+	if srv.CertificateIdentity != nil {
+		identity = srv.CertificateIdentity
+	}
+	device, err := identity(state.VerifiedChains[0][0])
+	if err != nil {
+		return err
+	}
+	s.Peer, s.Device, s.authenticated = device, device, true
+	srv.connect(s)
+	return nil
+}
+
+// CommonNameIdentity returns common name of certificate subject as device
+func CommonNameIdentity(cert *x509.Certificate) (string, error) {
+	if cert.Subject.CommonName == "" {
+		return "", errors.New("certificate has no common name")
+	}
+	return cert.Subject.CommonName, nil
+}
+
+// Client is device connection to server, requests are sent by embedded Exchange
+type Client struct {
+	Exchange
+	conn net.Conn
+}
+
+// Dial connects to TCP address, connection uses TLS if config is set
+func Dial(addr string, config *tls.Config) (*Client, error) {
+	var conn net.Conn
+	var err error
+	if config != nil {
+		conn, err = tls.Dial("tcp", addr, config)
+	} else {
+		conn, err = net.Dial("tcp", addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(conn), nil
+}
+
+func NewClient(conn net.Conn) *Client {
+	return &Client{Exchange: Exchange{Conn: conn}, conn: conn}
+}
+
+// ConnectionState returns TLS state, ok is false for plain connection
+func (c *Client) ConnectionState() (state tls.ConnectionState, ok bool) {
+	conn := c.conn
+	if ws, ok := conn.(*wsConn); ok {
+		conn = ws.Conn
+	}
+	if conn, ok := conn.(*tls.Conn); ok {
+		return conn.ConnectionState(), true
+	}
+	return
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
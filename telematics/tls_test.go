@@ -0,0 +1,136 @@
+package telematics
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/boiledgas/protocol/telematics/section"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pool *x509.CertPool
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return &testCA{cert: cert, key: key, pool: pool}
+}
+
+func (ca *testCA) issue(t *testing.T, name string, usage x509.ExtKeyUsage) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func Test_ServerTLS(t *testing.T) {
+	ca := newTestCA(t)
+	peers := make(chan string, 1)
+	srv := Server{
+		Authenticator: &StaticAuthenticator{Secrets: map[string][]byte{}},
+		Handler: HandlerFunc(func(s *Session, r *Request) Response {
+			peers <- s.Peer
+			return Response{Flags: RESPONSE_OK}
+		}),
+		TLSConfig: &tls.Config{
+			Certificates: []tls.Certificate{ca.issue(t, "server", x509.ExtKeyUsageServerAuth)},
+			ClientAuth:   tls.VerifyClientCertIfGiven,
+			ClientCAs:    ca.pool,
+		},
+	}
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go srv.Serve(l)
+
+	execute := Request{Sequence: Sequence(), Executes: []section.CommandExecute{{ModuleId: 1, CommandId: 1}}}
+	execute.Set(section.FLAG_COMMAND_EXECUTE, true)
+
+	client, err := Dial(l.Addr().String(), &tls.Config{
+		RootCAs:      ca.pool,
+		Certificates: []tls.Certificate{ca.issue(t, "dev1", x509.ExtKeyUsageClientAuth)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	client.Timeout = time.Second
+	resp, err := client.Send(&execute)
+	if err != nil || resp.Flags != RESPONSE_OK {
+		t.Fatalf("certificate session rejected: %v %v", resp, err)
+	}
+	if peer := <-peers; peer != "dev1" {
+		t.Errorf("peer wrong: %v", peer)
+	}
+	if state, ok := client.ConnectionState(); !ok || !state.HandshakeComplete {
+		t.Errorf("connection state wrong: %v", ok)
+	}
+
+	anonymous, err := Dial(l.Addr().String(), &tls.Config{RootCAs: ca.pool})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer anonymous.Close()
+	anonymous.Timeout = time.Second
+	if resp, err = anonymous.Send(&execute); err != nil || !resp.Has(RESPONSE_AUTHORIZATION|RESPONSE_ERROR) {
+		t.Errorf("session without certificate not rejected: %v %v", resp, err)
+	}
+
+	other := newTestCA(t)
+	untrusted, err := Dial(l.Addr().String(), &tls.Config{
+		RootCAs:      ca.pool,
+		Certificates: []tls.Certificate{other.issue(t, "dev2", x509.ExtKeyUsageClientAuth)},
+	})
+	if err == nil {
+		untrusted.Timeout = time.Second
+		if _, err = untrusted.Send(&execute); err == nil {
+			t.Error("certificate of other ca accepted")
+		}
+		untrusted.Close()
+	}
+}
@@ -0,0 +1,459 @@
+package telematics
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/boiledgas/protocol/telematics/section"
+	"github.com/boiledgas/protocol/telematics/value"
+)
+
+var ErrCommandNotFound = errors.New("command not found")
+
+type CommandState byte
+
+// command lifecycle
+const (
+	COMMAND_QUEUED       CommandState = 0x00 // waiting to be sent
+	COMMAND_SENT         CommandState = 0x01 // request written, response not received
+	COMMAND_ACKNOWLEDGED CommandState = 0x02 // device received request
+	COMMAND_COMPLETED    CommandState = 0x03 // device executed command
+	COMMAND_FAILED       CommandState = 0x04 // device returned error
+	COMMAND_TIMEDOUT     CommandState = 0x05 // no response in tracker timeout
+	COMMAND_EXPIRED      CommandState = 0x06 // not sent before expiry
+)
+
+func (s CommandState) String() string {
+	switch s {
+	case COMMAND_QUEUED:
+		return "Queued"
+	case COMMAND_SENT:
+		return "Sent"
+	case COMMAND_ACKNOWLEDGED:
+		return "Acknowledged"
+	case COMMAND_COMPLETED:
+		return "Completed"
+	case COMMAND_FAILED:
+		return "Failed"
+	case COMMAND_TIMEDOUT:
+		return "TimedOut"
+	case COMMAND_EXPIRED:
+		return "Expired"
+	}
+	return fmt.Sprintf("CommandState(%d)", byte(s))
+}
+
+// IsFinal returns true if state does not change anymore
+func (s CommandState) IsFinal() bool {
+	return s == COMMAND_COMPLETED || s == COMMAND_FAILED || s == COMMAND_TIMEDOUT || s == COMMAND_EXPIRED
+}
+
+// CommandRecord is tracked command execution of device
+type CommandRecord struct {
+	Id       uint64
+	Device   string
+	Sequence byte // sequence of request the command was sent in
+	Execute  section.CommandExecute
+	State    CommandState
+	Code     uint16 // result code reported by device
+	Created  time.Time
+	Updated  time.Time
+	Expires  time.Time // queued command is dropped after expiry, zero never expires
+	Sent     time.Time // time of request the command was sent in
+}
+
+func (rec *CommandRecord) expired(now time.Time) bool {
+	return !rec.Expires.IsZero() && !now.Before(rec.Expires)
+}
+
+// CommandStore keeps command records, implementations must be safe for concurrent use
+type CommandStore interface {
+	Add(rec CommandRecord) (uint64, error) // stores new record and returns its id
+	Put(rec CommandRecord) error
+	Get(id uint64) (CommandRecord, error) // ErrCommandNotFound if record not exists
+	Find(device string, states ...CommandState) ([]CommandRecord, error)
+}
+
+// MemoryCommandStore is CommandStore kept in memory
+type MemoryCommandStore struct {
+	mutex   sync.RWMutex
+	lastId  uint64
+	records map[uint64]CommandRecord
+}
+
+func NewMemoryCommandStore() *MemoryCommandStore {
+	return &MemoryCommandStore{records: make(map[uint64]CommandRecord)}
+}
+
+func (s *MemoryCommandStore) Add(rec CommandRecord) (uint64, error) {
+	s.mutex.Lock()
+	s.lastId++
+	rec.Id = s.lastId
+	s.records[rec.Id] = rec
+	s.mutex.Unlock()
+	return rec.Id, nil
+}
+
+func (s *MemoryCommandStore) Put(rec CommandRecord) error {
+	s.mutex.Lock()
+	s.records[rec.Id] = rec
+	s.mutex.Unlock()
+	return nil
+}
+
+func (s *MemoryCommandStore) Get(id uint64) (CommandRecord, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	rec, ok := s.records[id]
+	if !ok {
+		return rec, ErrCommandNotFound
+	}
+	return rec, nil
+}
+
+// Find returns records of device ordered by id, all states if none given
+func (s *MemoryCommandStore) Find(device string, states ...CommandState) (res []CommandRecord, err error) {
+	s.mutex.RLock()
+	for _, rec := range s.records {
+		if rec.Device != device {
+			continue
+		}
+		if len(states) == 0 {
+			res = append(res, rec)
+			continue
+		}
+		for _, state := range states {
+			if rec.State == state {
+				res = append(res, rec)
+				break
+			}
+		}
+	}
+	s.mutex.RUnlock()
+	sort.Slice(res, func(i, j int) bool { return res[i].Id < res[j].Id })
+	return
+}
+
+// Prune removes records in final state updated before time
+func (s *MemoryCommandStore) Prune(before time.Time) error {
+	s.mutex.Lock()
+	for id, rec := range s.records {
+		if rec.State.IsFinal() && rec.Updated.Before(before) {
+			delete(s.records, id)
+		}
+	}
+	s.mutex.Unlock()
+	return nil
+}
+
+// CommandResultProperty is property the device reports command results with,
+// its value is UInt made by EncodeCommandResult
+type CommandResultProperty struct {
+	ModuleId   byte
+	PropertyId byte
+}
+
+// EncodeCommandResult packs sequence of command request, success and result code
+func EncodeCommandResult(sequence byte, ok bool, code uint16) uint32 {
+	v := uint32(sequence) | uint32(code)<<16
+	if ok {
+		v |= 0x100
+	}
+	return v
+}
+
+func DecodeCommandResult(v uint32) (sequence byte, ok bool, code uint16) {
+	return byte(v), v&0x100 != 0, uint16(v >> 16)
+}
+
+// Tracker follows commands from queue to result, requests are matched with responses by sequence
+// and send time, it is safe for concurrent use
+type Tracker struct {
+	Store   CommandStore
+	Timeout time.Duration // sent commands without response time out, zero disables timeout
+	Result  *CommandResultProperty
+
+	now      func() time.Time
+	mutex    sync.Mutex      // serializes changes of records
+	reserved map[uint64]bool // commands of requests being sent
+}
+
+func NewTracker(store CommandStore, timeout time.Duration) *Tracker {
+	return &Tracker{Store: store, Timeout: timeout, now: time.Now}
+}
+
+func (t *Tracker) clock() time.Time {
+	if t.now == nil {
+		return time.Now()
+	}
+	return t.now()
+}
+
+// Queue stores command execution of device and returns its id
+func (t *Tracker) Queue(device string, e section.CommandExecute) (uint64, error) {
+	return t.QueueUntil(device, e, time.Time{})
+}
+
+// QueueUntil queues command which expires if not sent before expires,
+// identical queued command of device is not duplicated, its id is returned and expiry extended.
+// Arguments are converted to their data types in Types, argument of go type which is no data type
+// must have its type in Types
+func (t *Tracker) QueueUntil(device string, e section.CommandExecute, expires time.Time) (uint64, error) {
+	e, err := typed(e)
+	if err != nil {
+		return 0, err
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	queued, err := t.Store.Find(device, COMMAND_QUEUED)
+	if err != nil {
+		return 0, err
+	}
+	now := t.clock()
+	for _, rec := range queued {
+		if !sameExecute(rec.Execute, e) || rec.expired(now) {
+			continue
+		}
+		if !rec.Expires.IsZero() && (expires.IsZero() || expires.After(rec.Expires)) {
+			err = t.update(rec.Id, func(rec *CommandRecord) { rec.Expires = expires })
+		}
+		return rec.Id, err
+	}
+	return t.Store.Add(CommandRecord{Device: device, Execute: e, State: COMMAND_QUEUED, Created: now, Updated: now, Expires: expires})
+}
+
+// typed returns command with arguments converted to data types of Types
+func typed(e section.CommandExecute) (section.CommandExecute, error) {
+	args := make(map[byte]interface{}, len(e.Arguments))
+	for id, v := range e.Arguments {
+		t, ok := e.Types[id]
+		if !ok {
+			t = value.TypeOf(v)
+		}
+		if t == value.NotSet {
+			return e, fmt.Errorf("argument %v: value %v (%T) has no data type", id, v, v)
+		}
+		converted, err := value.Convert(t, v)
+		if err != nil {
+			return e, fmt.Errorf("argument %v: %v", id, err)
+		}
+		args[id] = converted
+	}
+	if e.Arguments != nil {
+		e.Arguments = args
+	}
+	return e, nil
+}
+
+func sameExecute(a section.CommandExecute, b section.CommandExecute) bool {
+	return a.ModuleId == b.ModuleId && a.CommandId == b.CommandId && reflect.DeepEqual(a.Arguments, b.Arguments)
+}
+
+// Request returns request with queued commands of device in queue order and ids of the commands,
+// expired commands are dropped, request has no sections if nothing is queued.
+// Commands stay queued until they are marked by Sent, they are not returned by other requests
+// until Sent or Release is called
+func (t *Tracker) Request(device string) (r Request, ids []uint64, err error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	var queued []CommandRecord
+	if queued, err = t.Store.Find(device, COMMAND_QUEUED); err != nil {
+		return
+	}
+	now := t.clock()
+	var send []CommandRecord
+	for _, rec := range queued {
+		if rec.expired(now) {
+			if err = t.update(rec.Id, func(rec *CommandRecord) { rec.State = COMMAND_EXPIRED }); err != nil {
+				return
+			}
+			continue
+		}
+		if t.reserved[rec.Id] {
+			continue
+		}
+		send = append(send, rec)
+	}
+	if len(send) == 0 {
+		return
+	}
+	r.Sequence = Sequence()
+	r.Timestamp = int32(now.Unix())
+	for _, rec := range send {
+		r.Executes = append(r.Executes, rec.Execute)
+		ids = append(ids, rec.Id)
+	}
+	r.Set(section.FLAG_COMMAND_EXECUTE, true)
+	if t.reserved == nil {
+		t.reserved = make(map[uint64]bool)
+	}
+	for _, id := range ids {
+		t.reserved[id] = true
+	}
+	return
+}
+
+// Deliver sends queued commands of device by send, called when device session is (re)authenticated.
+// Commands are marked sent only if send succeeds, otherwise they stay queued
+func (t *Tracker) Deliver(device string, send func(r *Request) error) (sent bool, err error) {
+	r, ids, err := t.Request(device)
+	if err != nil || len(ids) == 0 {
+		return
+	}
+	if err = send(&r); err != nil {
+		t.Release(ids...)
+		return
+	}
+	return true, t.Sent(r.Sequence, ids...)
+}
+
+// Sent marks commands written in request with sequence
+func (t *Tracker) Sent(sequence byte, ids ...uint64) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	now := t.clock()
+	for _, id := range ids {
+		delete(t.reserved, id)
+		err := t.update(id, func(rec *CommandRecord) {
+			rec.Sequence = sequence
+			rec.State = COMMAND_SENT
+			rec.Sent = now
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Release returns commands of request which was not sent to queue
+func (t *Tracker) Release(ids ...uint64) {
+	t.mutex.Lock()
+	for _, id := range ids {
+		delete(t.reserved, id)
+	}
+	t.mutex.Unlock()
+}
+
+// match returns records sent in last request with sequence, sequence of older requests is reused
+// after it wraps. Records sent timeout or more ago are not matched, zero timeout matches all
+func (t *Tracker) match(recs []CommandRecord, sequence byte, timeout time.Duration) (res []CommandRecord) {
+	now := t.clock()
+	var last time.Time
+	for _, rec := range recs {
+		if rec.Sequence != sequence || (timeout > 0 && now.Sub(rec.Sent) >= timeout) {
+			continue
+		}
+		if rec.Sent.After(last) {
+			last, res = rec.Sent, res[:0]
+		}
+		if rec.Sent.Equal(last) {
+			res = append(res, rec)
+		}
+	}
+	return
+}
+
+// Response updates commands sent in request with response sequence
+func (t *Tracker) Response(device string, resp Response) error {
+	state := COMMAND_ACKNOWLEDGED
+	if resp.Has(RESPONSE_ERROR) {
+		state = COMMAND_FAILED
+	} else if resp.Has(RESPONSE_EXECUTED) {
+		state = COMMAND_COMPLETED
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	sent, err := t.Store.Find(device, COMMAND_SENT)
+	if err != nil {
+		return err
+	}
+	for _, rec := range t.match(sent, resp.Sequence, t.Timeout) {
+		if err = t.update(rec.Id, func(rec *CommandRecord) { rec.State = state }); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Results applies command results reported by device in result property values
+func (t *Tracker) Results(device string, r *Request) error {
+	if t.Result == nil {
+		return nil
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	for _, s := range r.Values {
+		if s.ModuleId != t.Result.ModuleId {
+			continue
+		}
+		v, ok := s.Values[t.Result.PropertyId].(uint32)
+		if !ok {
+			continue
+		}
+		sequence, success, code := DecodeCommandResult(v)
+		recs, err := t.Store.Find(device, COMMAND_SENT, COMMAND_ACKNOWLEDGED)
+		if err != nil {
+			return err
+		}
+		for _, rec := range t.match(recs, sequence, 0) {
+			err = t.update(rec.Id, func(rec *CommandRecord) {
+				rec.Code = code
+				if success {
+					rec.State = COMMAND_COMPLETED
+				} else {
+					rec.State = COMMAND_FAILED
+				}
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Expire times out sent commands of device older than tracker timeout
+func (t *Tracker) Expire(device string) error {
+	if t.Timeout == 0 {
+		return nil
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	sent, err := t.Store.Find(device, COMMAND_SENT)
+	if err != nil {
+		return err
+	}
+	now := t.clock()
+	for _, rec := range sent {
+		if now.Sub(rec.Updated) < t.Timeout {
+			continue
+		}
+		if err = t.update(rec.Id, func(rec *CommandRecord) { rec.State = COMMAND_TIMEDOUT }); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *Tracker) State(id uint64) (CommandState, error) {
+	rec, err := t.Store.Get(id)
+	return rec.State, err
+}
+
+func (t *Tracker) update(id uint64, f func(rec *CommandRecord)) error {
+	rec, err := t.Store.Get(id)
+	if err != nil {
+		return err
+	}
+	if rec.State.IsFinal() {
+		return nil
+	}
+	f(&rec)
+	rec.Updated = t.clock()
+	return t.Store.Put(rec)
+}
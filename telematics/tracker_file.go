@@ -0,0 +1,127 @@
+package telematics
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// FileCommandStore is MemoryCommandStore saved to json file on every change,
+// queued commands survive restarts, change is rolled back if it can't be saved
+type FileCommandStore struct {
+	mutex  sync.Mutex // serializes saves
+	path   string
+	memory *MemoryCommandStore
+}
+
+type commandFile struct {
+	LastId  uint64          `json:"lastId"`
+	Records []CommandRecord `json:"records"`
+}
+
+// OpenFileCommandStore loads records from path, missing file is empty store
+func OpenFileCommandStore(path string) (*FileCommandStore, error) {
+	s := &FileCommandStore{path: path, memory: NewMemoryCommandStore()}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var f commandFile
+	if err = json.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+	s.memory.lastId = f.LastId
+	for _, rec := range f.Records {
+		s.memory.records[rec.Id] = rec
+	}
+	return s, nil
+}
+
+func (s *FileCommandStore) Add(rec CommandRecord) (id uint64, err error) {
+	if id, err = s.memory.Add(rec); err != nil {
+		return
+	}
+	if err = s.save(); err != nil {
+		s.restore(id, CommandRecord{}, false)
+		return 0, err
+	}
+	return
+}
+
+func (s *FileCommandStore) Put(rec CommandRecord) error {
+	old, getErr := s.memory.Get(rec.Id)
+	if err := s.memory.Put(rec); err != nil {
+		return err
+	}
+	if err := s.save(); err != nil {
+		s.restore(rec.Id, old, getErr == nil)
+		return err
+	}
+	return nil
+}
+
+// restore puts back record which was replaced by change not saved, record is removed if it did not exist
+func (s *FileCommandStore) restore(id uint64, old CommandRecord, existed bool) {
+	s.memory.mutex.Lock()
+	if existed {
+		s.memory.records[id] = old
+	} else {
+		delete(s.memory.records, id)
+	}
+	s.memory.mutex.Unlock()
+}
+
+func (s *FileCommandStore) Get(id uint64) (CommandRecord, error) {
+	return s.memory.Get(id)
+}
+
+func (s *FileCommandStore) Find(device string, states ...CommandState) ([]CommandRecord, error) {
+	return s.memory.Find(device, states...)
+}
+
+func (s *FileCommandStore) Prune(before time.Time) error {
+	if err := s.memory.Prune(before); err != nil {
+		return err
+	}
+	return s.save()
+}
+
+// save writes temporary file and renames it, file is never left half written
+func (s *FileCommandStore) save() (err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.memory.mutex.RLock()
+	f := commandFile{LastId: s.memory.lastId, Records: make([]CommandRecord, 0, len(s.memory.records))}
+	for _, rec := range s.memory.records {
+		f.Records = append(f.Records, rec)
+	}
+	s.memory.mutex.RUnlock()
+	sort.Slice(f.Records, func(i, j int) bool { return f.Records[i].Id < f.Records[j].Id })
+
+	var data []byte
+	if data, err = json.Marshal(f); err != nil {
+		return
+	}
+	tmp := s.path + ".tmp"
+	var file *os.File
+	if file, err = os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600); err != nil {
+		return
+	}
+	if _, err = file.Write(data); err == nil {
+		err = file.Sync()
+	}
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return
+	}
+	return os.Rename(tmp, s.path)
+}
@@ -0,0 +1,361 @@
+package telematics
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"log"
+	"net"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/boiledgas/protocol/telematics/section"
+	"github.com/boiledgas/protocol/telematics/value"
+)
+
+// deliver returns request of commands delivered to device
+func deliver(tracker *Tracker, device string) (r Request, err error) {
+	_, err = tracker.Deliver(device, func(req *Request) error {
+		r = *req
+		return nil
+	})
+	return
+}
+
+func Test_TrackerResponse(t *testing.T) {
+	tracker := NewTracker(NewMemoryCommandStore(), time.Minute)
+	e := section.CommandExecute{ModuleId: 2, CommandId: 5, Arguments: map[byte]interface{}{}}
+	id1, _ := tracker.Queue("dev1", e)
+	id2, _ := tracker.Queue("dev1", section.CommandExecute{ModuleId: 2, CommandId: 6})
+	req, err := deliver(tracker, "dev1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(req.Executes) != 2 || !req.Has(section.FLAG_COMMAND_EXECUTE) {
+		t.Fatalf("request wrong: %v", req)
+	}
+	if state, _ := tracker.State(id1); state != COMMAND_SENT {
+		t.Errorf("state wrong: %v", state)
+	}
+
+	tracker.Response("dev1", Response{Sequence: req.Sequence + 1})
+	if state, _ := tracker.State(id1); state != COMMAND_SENT {
+		t.Errorf("other sequence changed state: %v", state)
+	}
+	tracker.Response("dev1", Response{Sequence: req.Sequence})
+	if state, _ := tracker.State(id2); state != COMMAND_ACKNOWLEDGED {
+		t.Errorf("state wrong: %v", state)
+	}
+
+	id3, _ := tracker.Queue("dev1", e)
+	req, _ = deliver(tracker, "dev1")
+	tracker.Response("dev1", Response{Sequence: req.Sequence, Flags: RESPONSE_EXECUTED})
+	if state, _ := tracker.State(id3); state != COMMAND_COMPLETED {
+		t.Errorf("state wrong: %v", state)
+	}
+	tracker.Response("dev1", Response{Sequence: req.Sequence, Flags: RESPONSE_ERROR})
+	if state, _ := tracker.State(id3); state != COMMAND_COMPLETED {
+		t.Errorf("final state changed: %v", state)
+	}
+}
+
+func Test_TrackerResultAndTimeout(t *testing.T) {
+	now := time.Unix(1500000000, 0)
+	tracker := NewTracker(NewMemoryCommandStore(), time.Minute)
+	tracker.now = func() time.Time { return now }
+	tracker.Result = &CommandResultProperty{ModuleId: 9, PropertyId: 1}
+	e := section.CommandExecute{ModuleId: 2, CommandId: 5}
+
+	id1, _ := tracker.Queue("dev1", e)
+	req1, _ := deliver(tracker, "dev1")
+	tracker.Response("dev1", Response{Sequence: req1.Sequence})
+	id2, _ := tracker.Queue("dev1", e)
+	deliver(tracker, "dev1")
+
+	report := Request{}
+	report.Values = []section.ModulePropertyValue{{
+		ModuleId: 9,
+		Values:   map[byte]interface{}{1: EncodeCommandResult(req1.Sequence, false, 42)},
+		Types:    map[byte]value.DataType{1: value.UInt},
+	}}
+	if err := tracker.Results("dev1", &report); err != nil {
+		t.Fatal(err)
+	}
+	rec, _ := tracker.Store.Get(id1)
+	if rec.State != COMMAND_FAILED || rec.Code != 42 {
+		t.Errorf("result wrong: %v", rec)
+	}
+
+	now = now.Add(2 * time.Minute)
+	tracker.Expire("dev1")
+	if state, _ := tracker.State(id2); state != COMMAND_TIMEDOUT {
+		t.Errorf("state wrong: %v", state)
+	}
+	if _, err := tracker.State(100); err != ErrCommandNotFound {
+		t.Errorf("not found error wrong: %v", err)
+	}
+}
+
+func Test_TrackerOfflineQueue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "commands.json")
+	store, err := OpenFileCommandStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1500000000, 0)
+	tracker := NewTracker(store, time.Minute)
+	tracker.now = func() time.Time { return now }
+
+	block := section.CommandExecute{ModuleId: 2, CommandId: 5, Arguments: map[byte]interface{}{1: uint16(30)}, Types: map[byte]value.DataType{1: value.UShort}}
+	reboot := section.CommandExecute{ModuleId: 1, CommandId: 1, Arguments: map[byte]interface{}{}, Types: map[byte]value.DataType{}}
+	id1, _ := tracker.QueueUntil("dev1", block, now.Add(time.Hour))
+	id2, _ := tracker.QueueUntil("dev1", reboot, now.Add(time.Second))
+	if id, _ := tracker.Queue("dev1", block); id != id1 {
+		t.Errorf("identical command duplicated: %v != %v", id, id1)
+	}
+
+	// server restart
+	store, err = OpenFileCommandStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracker = NewTracker(store, time.Minute)
+	now = now.Add(2 * time.Hour)
+	tracker.now = func() time.Time { return now }
+	id3, _ := tracker.Queue("dev1", reboot)
+
+	conf := testConfiguration()
+	buf := bytes.Buffer{}
+	w := NewWriter(&buf)
+	w.Configuration = &conf
+	if sent, err := tracker.Deliver("dev1", w.WriteRequest); err != nil || !sent {
+		t.Fatalf("deliver failed: %v %v", sent, err)
+	}
+	r := NewReader(&buf)
+	r.Configuration = &conf
+	req := Request{}
+	if err := r.ReadRequest(&req); err != nil {
+		t.Fatal(err)
+	}
+	if len(req.Executes) != 2 || req.Executes[0].CommandId != 5 || req.Executes[1].CommandId != 1 {
+		t.Errorf("delivered commands wrong: %v", req.Executes)
+	}
+	if state, _ := tracker.State(id2); state != COMMAND_EXPIRED {
+		t.Errorf("state wrong: %v", state)
+	}
+	if state, _ := tracker.State(id3); state != COMMAND_SENT {
+		t.Errorf("state wrong: %v", state)
+	}
+	if sent, _ := tracker.Deliver("dev1", w.WriteRequest); sent {
+		t.Error("nothing queued but delivered")
+	}
+}
+
+type failingWriter struct {
+	err error
+	buf bytes.Buffer
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	return w.buf.Write(p)
+}
+
+func Test_TrackerDeliverFailed(t *testing.T) {
+	tracker := NewTracker(NewMemoryCommandStore(), time.Minute)
+	id, _ := tracker.Queue("dev1", section.CommandExecute{ModuleId: 2, CommandId: 5, Arguments: map[byte]interface{}{}})
+	srv := Server{Tracker: tracker}
+	conn := &failingWriter{err: errors.New("connection reset")}
+	s := NewSession(conn)
+	s.Configuration = testConfiguration()
+	s.setAuthenticated("dev1", true)
+
+	if err := srv.Deliver(s); err != conn.err {
+		t.Errorf("write error not returned: %v", err)
+	}
+	if state, _ := tracker.State(id); state != COMMAND_QUEUED {
+		t.Errorf("unwritten command state wrong: %v", state)
+	}
+	conn.err = nil
+	if err := srv.Deliver(s); err != nil {
+		t.Fatal(err)
+	}
+	if state, _ := tracker.State(id); state != COMMAND_SENT || conn.buf.Len() == 0 {
+		t.Errorf("command not sent: %v", state)
+	}
+}
+
+func Test_TrackerSequenceReused(t *testing.T) {
+	now := time.Unix(1500000000, 0)
+	tracker := NewTracker(NewMemoryCommandStore(), time.Minute)
+	tracker.now = func() time.Time { return now }
+	e := section.CommandExecute{ModuleId: 2, CommandId: 5}
+
+	id1, _ := tracker.Queue("dev1", e)
+	tracker.Sent(7, id1)
+	now = now.Add(time.Second)
+	id2, _ := tracker.Queue("dev1", section.CommandExecute{ModuleId: 2, CommandId: 6})
+	tracker.Sent(7, id2)
+	tracker.Response("dev1", Response{Sequence: 7})
+	if state, _ := tracker.State(id1); state != COMMAND_SENT {
+		t.Errorf("command of earlier request with same sequence changed: %v", state)
+	}
+	if state, _ := tracker.State(id2); state != COMMAND_ACKNOWLEDGED {
+		t.Errorf("state wrong: %v", state)
+	}
+
+	now = now.Add(2 * time.Minute)
+	tracker.Response("dev1", Response{Sequence: 7})
+	if state, _ := tracker.State(id1); state != COMMAND_SENT {
+		t.Errorf("late response changed timed out command: %v", state)
+	}
+}
+
+func Test_TrackerConcurrentDeliver(t *testing.T) {
+	tracker := NewTracker(NewMemoryCommandStore(), time.Minute)
+	e := section.CommandExecute{ModuleId: 2, CommandId: 5, Arguments: map[byte]interface{}{}}
+	srv := Server{Tracker: tracker}
+	conn := &failingWriter{}
+	s := NewSession(conn)
+	s.Configuration = testConfiguration()
+	s.setAuthenticated("dev1", true)
+
+	var wg sync.WaitGroup
+	ids := make([]uint64, 8)
+	for i := range ids {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ids[i], _ = tracker.Queue("dev1", e)
+		}(i)
+	}
+	wg.Wait()
+	for _, id := range ids {
+		if id != ids[0] {
+			t.Fatalf("identical command queued twice: %v", ids)
+		}
+	}
+	for range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := srv.Deliver(s); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	r := NewReader(&conn.buf)
+	r.Configuration = &s.Configuration
+	sent := 0
+	for conn.buf.Len() > 0 {
+		req := Request{}
+		if err := r.ReadRequest(&req); err != nil {
+			t.Fatal(err)
+		}
+		sent += len(req.Executes)
+	}
+	if sent != 1 {
+		t.Errorf("command sent %v times", sent)
+	}
+
+	tracker.Queue("dev1", section.CommandExecute{ModuleId: 2, CommandId: 6})
+	_, reserved, _ := tracker.Request("dev1")
+	if _, other, _ := tracker.Request("dev1"); len(reserved) != 1 || len(other) != 0 {
+		t.Errorf("command being sent returned by other request: %v %v", reserved, other)
+	}
+	tracker.Release(reserved...)
+	if _, released, _ := tracker.Request("dev1"); len(released) != 1 {
+		t.Errorf("released command not queued: %v", released)
+	}
+}
+
+func Test_FileCommandStoreRollback(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "commands.json")
+	store, err := OpenFileCommandStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracker := NewTracker(store, time.Minute)
+	block := section.CommandExecute{ModuleId: 2, CommandId: 5, Arguments: map[byte]interface{}{1: 30}}
+	if _, err := tracker.Queue("dev1", block); err == nil {
+		t.Error("argument without data type queued")
+	}
+	block.Types = map[byte]value.DataType{1: value.UShort}
+	id, err := tracker.Queue("dev1", block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec, _ := store.Get(id); rec.Execute.Arguments[1] != uint16(30) {
+		t.Errorf("argument not converted: %T", rec.Execute.Arguments[1])
+	}
+
+	store.path = filepath.Join(path, "commands.json") // parent is file, save fails
+	if _, err := store.Add(CommandRecord{Device: "dev1"}); err == nil {
+		t.Error("unsaved record added")
+	}
+	if err := store.Put(CommandRecord{Id: id, Device: "dev1", State: COMMAND_SENT}); err == nil {
+		t.Error("unsaved record put")
+	}
+	if recs, _ := store.Find("dev1"); len(recs) != 1 || recs[0].State != COMMAND_QUEUED {
+		t.Errorf("unsaved changes kept: %v", recs)
+	}
+	store.path = path
+	if _, err := tracker.Queue("dev2", block); err != nil {
+		t.Errorf("store broken after failed save: %v", err)
+	}
+}
+
+func Test_ServerDeliverWithoutConfiguration(t *testing.T) {
+	conf := testConfiguration()
+	tracker := NewTracker(NewMemoryCommandStore(), time.Minute)
+	tracker.Queue("42", section.CommandExecute{ModuleId: 2, CommandId: 5, Arguments: map[byte]interface{}{1: uint16(30)}})
+	srv := Server{Tracker: tracker, State: NewDeviceState(), ErrorLog: log.New(ioutil.Discard, "", 0)}
+	device, conn := net.Pipe()
+	defer device.Close()
+	go srv.ServeConn(conn)
+
+	w := NewWriter(device)
+	reader := NewReader(device)
+	reader.Configuration = &conf
+	hello := Request{Sequence: Sequence(), Id: section.Identification{Code: 42}}
+	hello.Id.Set(section.IDENTIFICATION_FLAGS_CODE, true)
+	hello.Set(section.FLAG_IDENTIFICATION, true)
+	send := func(r Request) {
+		if err := w.WriteRequest(&r); err != nil {
+			t.Fatal(err)
+		}
+		p := Packet{}
+		if err := reader.Read(&p); err != nil || !p.Has(FLAG_RESPONSE) {
+			t.Fatalf("request not answered: %v %v", p, err)
+		}
+	}
+	send(hello)
+	send(Request{Sequence: Sequence()})
+
+	// configuration of device known from earlier connection
+	known := Request{Conf: conf}
+	known.Set(section.FLAG_MODULE, true)
+	known.Set(section.FLAG_COMMAND, true)
+	known.Set(section.FLAG_COMMAND_ARGUMENT, true)
+	srv.State.Update("42", &known)
+	go w.WriteRequest(&Request{Sequence: Sequence()}) // command is delivered after this or previous request
+	for i := 0; i < 2; i++ {
+		p := Packet{}
+		if err := reader.Read(&p); err != nil {
+			t.Fatal(err)
+		}
+		if p.Has(FLAG_REQUEST) {
+			if len(p.Request.Executes) != 1 || p.Request.Executes[0].Arguments[1] != uint16(30) {
+				t.Errorf("command delivered wrong: %v", p.Request.Executes)
+			}
+			return
+		}
+	}
+	t.Error("command not delivered with known configuration")
+}
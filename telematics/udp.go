@@ -0,0 +1,192 @@
+package telematics
+
+import (
+	"bytes"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/boiledgas/protocol/telematics/section"
+)
+
+const UDP_SESSION_EXPIRY = 5 * time.Minute
+
+// UDPServer serves datagrams of devices, each datagram is one packet answered to sender address.
+// Session is found by sender address, datagram of new address starts new session. If server has
+// authenticator and new session authenticates as device of other session, the other session moves
+// to new address and keeps its configuration and state. Without authenticator sessions never move,
+// session of old address expires and new session identified as the device gets its known configuration. Session expires after Expiry without datagrams since UDP has no disconnect
+type UDPServer struct {
+	Server *Server
+	Expiry time.Duration // UDP_SESSION_EXPIRY if zero
+
+	mutex    sync.Mutex
+	sessions map[string]*udpSession // by sender address
+	swept    time.Time
+}
+
+type udpSession struct {
+	session *Session
+	conn    *datagramConn
+	seen    time.Time
+}
+
+// datagramConn writes each packet of session as datagram to address
+type datagramConn struct {
+	conn net.PacketConn
+	addr net.Addr
+}
+
+func (c *datagramConn) Write(p []byte) (int, error) {
+	return c.conn.WriteTo(p, c.addr)
+}
+
+func (u *UDPServer) expiry() time.Duration {
+	if u.Expiry <= 0 {
+		return UDP_SESSION_EXPIRY
+	}
+	return u.Expiry
+}
+
+// ListenAndServe listens on UDP address and serves datagrams
+func (u *UDPServer) ListenAndServe(addr string) error {
+	conn, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	return u.Serve(conn)
+}
+
+// Serve reads datagrams until connection fails, expired sessions are removed while waiting
+func (u *UDPServer) Serve(conn net.PacketConn) error {
+	buf := make([]byte, 65536)
+	for {
+		if err := conn.SetReadDeadline(time.Now().Add(u.expiry())); err != nil {
+			return err
+		}
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			if isTimeout(err) {
+				u.Expire()
+				continue
+			}
+			return err
+		}
+		if err = u.ServeDatagram(conn, addr, buf[:n]); err != nil {
+			u.Server.logf("telematics: %v: %v", addr, err)
+		}
+		if time.Since(u.swept) > u.expiry() {
+			u.Expire()
+		}
+	}
+}
+
+// ServeDatagram decodes datagram of sender and serves it in session of sender
+func (u *UDPServer) ServeDatagram(conn net.PacketConn, addr net.Addr, data []byte) error {
+	u.mutex.Lock()
+	if u.sessions == nil {
+		u.sessions = make(map[string]*udpSession)
+	}
+	us := u.sessions[addr.String()]
+	var conf *Configuration
+	if us != nil {
+		conf = &us.session.Configuration
+	}
+	p := Packet{}
+	if err := readDatagram(data, conf, u.Server.Metrics, &p); err != nil {
+		u.mutex.Unlock()
+		return err
+	}
+	if us == nil {
+		c := &datagramConn{conn: conn, addr: addr}
+		us = &udpSession{session: NewSession(c), conn: c}
+		u.sessions[addr.String()] = us
+		u.Server.register(us.session)
+	}
+	us.seen = time.Now()
+	u.mutex.Unlock()
+	u.seed(us.session, &p)
+	authenticated := us.session.Authenticated()
+	err := u.Server.ServePacket(us.session, &p)
+	if u.Server.Authenticator != nil && !authenticated && us.session.Authenticated() {
+		u.merge(us)
+	}
+	return err
+}
+
+// seed sets configuration known for device to session without configuration identified by request,
+// so values of device are decoded after its address changed. Server with authenticator moves session instead
+func (u *UDPServer) seed(s *Session, p *Packet) {
+	r := &p.Request
+	if u.Server.Authenticator != nil || !p.Has(FLAG_REQUEST) || !r.Has(section.FLAG_IDENTIFICATION) || r.HasConfiguration() {
+		return
+	}
+	if len(s.State().Configuration.Modules) > 0 {
+		return
+	}
+	conf, ok := u.Server.Configuration(DeviceId(r.Id))
+	if !ok {
+		return
+	}
+	s.state.Lock()
+	if len(s.Configuration.Modules) == 0 {
+		s.Configuration = conf
+		s.Disabled.Init(&s.Configuration)
+	}
+	s.state.Unlock()
+}
+
+func readDatagram(data []byte, conf *Configuration, metrics Metrics, p *Packet) error {
+	reader := NewReader(bytes.NewReader(data))
+	reader.Configuration = conf
+	reader.Metrics = metrics
+	return readPacket(reader, p)
+}
+
+// merge moves other authenticated session of device of just authenticated session to its address,
+// just authenticated session is dropped
+func (u *UDPServer) merge(us *udpSession) {
+	device := us.session.State().Device
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+	for addr, old := range u.sessions {
+		if old == us || !old.session.Authenticated() || old.session.State().Device != device {
+			continue
+		}
+		delete(u.sessions, addr)
+		old.session.mutex.Lock()
+		old.conn.addr = us.conn.addr
+		old.session.mutex.Unlock()
+		old.seen = us.seen
+		u.sessions[us.conn.addr.String()] = old
+		u.Server.unregister(us.session)
+		return
+	}
+}
+
+// Expire removes sessions without datagrams for Expiry and returns them
+func (u *UDPServer) Expire() (expired []*Session) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+	now := time.Now()
+	for addr, us := range u.sessions {
+		if now.Sub(us.seen) >= u.expiry() {
+			delete(u.sessions, addr)
+			u.Server.unregister(us.session)
+			expired = append(expired, us.session)
+		}
+	}
+	u.swept = now
+	return
+}
+
+// Sessions returns active sessions
+func (u *UDPServer) Sessions() (sessions []*Session) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+	for _, us := range u.sessions {
+		sessions = append(sessions, us.session)
+	}
+	return
+}
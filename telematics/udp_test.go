@@ -0,0 +1,218 @@
+package telematics
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/boiledgas/protocol/telematics/section"
+	"github.com/boiledgas/protocol/telematics/value"
+)
+
+// sendDatagram sends request to server and returns response
+func sendDatagram(t *testing.T, client net.PacketConn, server net.Addr, conf *Configuration, r Request) Response {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.Configuration = conf
+	if err := w.WriteRequest(&r); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.WriteTo(buf.Bytes(), server); err != nil {
+		t.Fatal(err)
+	}
+	data := make([]byte, 512)
+	client.SetReadDeadline(time.Now().Add(time.Second))
+	n, _, err := client.ReadFrom(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := Packet{}
+	if err := NewReader(bytes.NewReader(data[:n])).Read(&p); err != nil || !p.Has(FLAG_RESPONSE) {
+		t.Fatalf("response not read: %v", err)
+	}
+	return p.Response
+}
+
+func Test_UDPServer(t *testing.T) {
+	devices := make(chan string, 4)
+	u := UDPServer{
+		Server: &Server{Handler: HandlerFunc(func(s *Session, r *Request) Response {
+			devices <- s.Device
+			return Response{Flags: RESPONSE_OK}
+		})},
+		Expiry: 100 * time.Millisecond,
+	}
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	go u.Serve(conn)
+
+	send := func(client net.PacketConn, r Request) Response {
+		return sendDatagram(t, client, conn.LocalAddr(), nil, r)
+	}
+	hello := Request{Sequence: Sequence(), Id: section.Identification{CodeText: "dev1"}}
+	hello.Id.Set(section.IDENTIFICATION_FLAGS_CODETEXT, true)
+	hello.Set(section.FLAG_IDENTIFICATION, true)
+
+	client1, _ := net.ListenPacket("udp", "127.0.0.1:0")
+	defer client1.Close()
+	if resp := send(client1, hello); resp.Sequence != hello.Sequence || resp.Flags != RESPONSE_OK {
+		t.Errorf("response wrong: %v", resp)
+	}
+	if device := <-devices; device != "dev1" {
+		t.Errorf("device wrong: %v", device)
+	}
+	empty := Request{Sequence: Sequence()}
+	send(client1, empty)
+	if device := <-devices; device != "dev1" {
+		t.Errorf("session of address not found: %v", device)
+	}
+
+	client2, _ := net.ListenPacket("udp", "127.0.0.1:0")
+	defer client2.Close()
+	send(client2, hello)
+	<-devices
+	if sessions := u.Sessions(); len(sessions) != 2 {
+		t.Errorf("session of device moved without authentication: %v", len(sessions))
+	}
+
+	time.Sleep(300 * time.Millisecond)
+	if sessions := u.Sessions(); len(sessions) != 0 {
+		t.Errorf("sessions not expired: %v", len(sessions))
+	}
+}
+
+func Test_UDPServerSpoofedIdentification(t *testing.T) {
+	devices := make(chan net.Addr, 4)
+	var u *UDPServer
+	u = &UDPServer{Server: &Server{
+		Authenticator: &StaticAuthenticator{Secrets: map[string][]byte{"dev1": []byte("secret")}},
+		Handler: HandlerFunc(func(s *Session, r *Request) Response {
+			if r.Has(section.FLAG_MODULE_PROPERTY_VALUE) {
+				u.mutex.Lock()
+				for _, us := range u.sessions {
+					if us.session == s {
+						devices <- us.conn.addr
+					}
+				}
+				u.mutex.Unlock()
+			}
+			return Response{Flags: RESPONSE_OK}
+		}),
+	}}
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	go u.Serve(conn)
+	conf := Configuration{Modules: []section.Module{{Id: 3}}, Properties: []section.ModuleProperty{{ModuleId: 3, Id: 1, Type: value.Bool}}}
+	send := func(client net.PacketConn, r Request) Response {
+		return sendDatagram(t, client, conn.LocalAddr(), &conf, r)
+	}
+	hello := Request{Sequence: Sequence(), Id: section.Identification{CodeText: "dev1"}, Conf: conf}
+	hello.Id.Set(section.IDENTIFICATION_FLAGS_CODETEXT, true)
+	for _, flag := range []uint16{section.FLAG_IDENTIFICATION, section.FLAG_MODULE, section.FLAG_MODULE_PROPERTY} {
+		hello.Set(flag, true)
+	}
+	values := func() Request {
+		r := Request{Sequence: Sequence(), Values: []section.ModulePropertyValue{{ModuleId: 3, Values: map[byte]interface{}{1: true}}}}
+		r.Set(section.FLAG_MODULE_PROPERTY_VALUE, true)
+		return r
+	}
+
+	device, _ := net.ListenPacket("udp", "127.0.0.1:0")
+	defer device.Close()
+	send(device, hello)
+	if resp := send(device, authRequest("dev1", []byte("secret"))); resp.Flags != RESPONSE_OK {
+		t.Fatalf("authentication failed: %v", resp.Flags)
+	}
+
+	spoofer, _ := net.ListenPacket("udp", "127.0.0.1:0")
+	defer spoofer.Close()
+	send(spoofer, hello)
+	send(spoofer, authRequest("dev1", []byte("wrong")))
+	if resp := send(spoofer, values()); !resp.Has(RESPONSE_AUTHORIZATION | RESPONSE_ERROR) {
+		t.Errorf("spoofed session authenticated: %v", resp.Flags)
+	}
+	if resp := send(device, values()); resp.Flags != RESPONSE_OK {
+		t.Errorf("session of device taken over: %v", resp.Flags)
+	}
+	if addr := <-devices; addr.String() != device.LocalAddr().String() {
+		t.Errorf("session address wrong: %v", addr)
+	}
+
+	moved, _ := net.ListenPacket("udp", "127.0.0.1:0")
+	defer moved.Close()
+	send(moved, authRequest("dev1", []byte("secret")))
+	send(moved, values())
+	if addr := <-devices; addr.String() != moved.LocalAddr().String() {
+		t.Errorf("session not moved to authenticated address: %v", addr)
+	}
+	if sessions := u.Sessions(); len(sessions) != 2 {
+		t.Errorf("sessions wrong: %v", len(sessions))
+	}
+}
+
+func Test_UDPServerRebind(t *testing.T) {
+	received := make(chan Request, 4)
+	u := UDPServer{Server: &Server{Handler: HandlerFunc(func(s *Session, r *Request) Response {
+		if r.Has(section.FLAG_MODULE_PROPERTY_VALUE) {
+			received <- *r
+		}
+		return Response{Flags: RESPONSE_OK}
+	})}}
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	go u.Serve(conn)
+	conf := Configuration{Modules: []section.Module{{Id: 3}}, Properties: []section.ModuleProperty{{ModuleId: 3, Id: 1, Type: value.Bool}}}
+	send := func(client net.PacketConn, r Request) Response {
+		return sendDatagram(t, client, conn.LocalAddr(), &conf, r)
+	}
+	hello := Request{Sequence: Sequence(), Id: section.Identification{CodeText: "dev1"}, Conf: conf}
+	hello.Id.Set(section.IDENTIFICATION_FLAGS_CODETEXT, true)
+	for _, flag := range []uint16{section.FLAG_IDENTIFICATION, section.FLAG_MODULE, section.FLAG_MODULE_PROPERTY} {
+		hello.Set(flag, true)
+	}
+	values := Request{Sequence: Sequence(), Values: []section.ModulePropertyValue{{ModuleId: 3, Values: map[byte]interface{}{1: true}}}}
+	values.Set(section.FLAG_MODULE_PROPERTY_VALUE, true)
+
+	device, _ := net.ListenPacket("udp", "127.0.0.1:0")
+	defer device.Close()
+	send(device, hello)
+
+	// address of device changed, device identifies itself without configuration
+	rebound, _ := net.ListenPacket("udp", "127.0.0.1:0")
+	defer rebound.Close()
+	id := Request{Sequence: Sequence(), Id: hello.Id}
+	id.Set(section.FLAG_IDENTIFICATION, true)
+	send(rebound, id)
+	if resp := send(rebound, values); resp.Flags != RESPONSE_OK {
+		t.Fatalf("values not served: %v", resp.Flags)
+	}
+	if r := <-received; r.Values[0].Values[1] != true {
+		t.Errorf("values wrong: %v", r.Values)
+	}
+	if sessions := u.Sessions(); len(sessions) != 2 {
+		t.Errorf("session of device moved without authentication: %v", len(sessions))
+	}
+
+	// session without identification gets no configuration
+	stranger, _ := net.ListenPacket("udp", "127.0.0.1:0")
+	defer stranger.Close()
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.Configuration = &conf
+	w.WriteRequest(&values)
+	stranger.WriteTo(buf.Bytes(), conn.LocalAddr())
+	stranger.SetReadDeadline(time.Now().Add(200 * time.Millisecond))
+	if _, _, err := stranger.ReadFrom(make([]byte, 512)); err == nil {
+		t.Error("values of session without configuration served")
+	}
+}
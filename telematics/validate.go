@@ -0,0 +1,153 @@
+package telematics
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/boiledgas/protocol/telematics/section"
+)
+
+var ErrPropertyNotReadable = errors.New("property not readable")
+
+// Violation is value of request not matching configuration,
+// ArgumentId is set for command arguments only
+type Violation struct {
+	ModuleId   byte
+	PropertyId byte
+	CommandId  byte
+	ArgumentId byte
+	Value      interface{}
+	Err        error
+}
+
+func (v Violation) IsArgument() bool {
+	return v.CommandId != 0 || v.ArgumentId != 0
+}
+
+func (v Violation) Error() string {
+	if v.IsArgument() {
+		return fmt.Sprintf("argument %v.%v.%v = %v: %v", v.ModuleId, v.CommandId, v.ArgumentId, v.Value, v.Err)
+	}
+	return fmt.Sprintf("property %v.%v = %v: %v", v.ModuleId, v.PropertyId, v.Value, v.Err)
+}
+
+func (v Violation) Unwrap() error {
+	return v.Err
+}
+
+// ValidationError is returned by reader in validation mode, request is read completely
+type ValidationError struct {
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	var buf bytes.Buffer
+	buf.WriteString("request not valid:")
+	for _, v := range e.Violations {
+		buf.WriteString(" ")
+		buf.WriteString(v.Error())
+		buf.WriteString(";")
+	}
+	return buf.String()
+}
+
+// Validator checks values and command arguments of decoded requests against configuration
+type Validator struct {
+	Configuration *Configuration
+}
+
+// Validate returns violations ordered by module, property, command and argument id
+func (v *Validator) Validate(r *Request) (violations []Violation) {
+	for _, s := range r.Values {
+		for id, val := range s.Values {
+			if err := v.checkValue(s.ModuleId, id, val); err != nil {
+				violations = append(violations, Violation{ModuleId: s.ModuleId, PropertyId: id, Value: val, Err: err})
+			}
+		}
+	}
+	for _, e := range r.Executes {
+		violations = append(violations, v.checkExecute(&e)...)
+	}
+	sort.Slice(violations, func(i, j int) bool {
+		a, b := violations[i], violations[j]
+		if a.ModuleId != b.ModuleId {
+			return a.ModuleId < b.ModuleId
+		}
+		if a.PropertyId != b.PropertyId {
+			return a.PropertyId < b.PropertyId
+		}
+		if a.CommandId != b.CommandId {
+			return a.CommandId < b.CommandId
+		}
+		return a.ArgumentId < b.ArgumentId
+	})
+	return
+}
+
+// Quarantine removes property values violating configuration from request and returns them
+func (v *Validator) Quarantine(r *Request) (violations []Violation) {
+	for _, violation := range v.Validate(r) {
+		if violation.IsArgument() {
+			continue
+		}
+		violations = append(violations, violation)
+		for _, s := range r.Values {
+			if s.ModuleId == violation.ModuleId {
+				delete(s.Values, violation.PropertyId)
+				delete(s.Types, violation.PropertyId)
+			}
+		}
+	}
+	values := r.Values[:0]
+	for _, s := range r.Values {
+		if len(s.Values) > 0 {
+			values = append(values, s)
+		}
+	}
+	r.Values = values
+	if len(values) == 0 {
+		r.Set(section.FLAG_MODULE_PROPERTY_VALUE, false)
+	}
+	return
+}
+
+func (v *Validator) checkValue(moduleId byte, propertyId byte, val interface{}) error {
+	var p section.ModuleProperty
+	if !v.Configuration.GetProperty(moduleId, propertyId, &p) {
+		return ErrPropertyUnknown
+	}
+	if p.Has(section.MODULE_PROPERTY_FLAGS_ACCESS) && !p.HasAccess(section.PROPERTYACCESS_READ) {
+		return ErrPropertyNotReadable
+	}
+	_, err := p.Check(val)
+	return err
+}
+
+func (v *Validator) checkExecute(e *section.CommandExecute) (violations []Violation) {
+	for _, arg := range v.Configuration.Arguments {
+		if arg.ModuleId != e.ModuleId || arg.CommandId != e.CommandId {
+			continue
+		}
+		val, ok := e.Arguments[arg.Id]
+		var err error
+		if !ok {
+			if arg.IsRequired() {
+				err = ErrArgumentRequired
+			}
+		} else {
+			_, err = arg.Check(val)
+		}
+		if err != nil {
+			violations = append(violations, Violation{ModuleId: e.ModuleId, CommandId: e.CommandId, ArgumentId: arg.Id, Value: val, Err: err})
+		}
+	}
+	var arg section.CommandArgument
+	for id, val := range e.Arguments {
+		if !v.Configuration.GetArgument(e.ModuleId, e.CommandId, id, &arg) {
+			violations = append(violations, Violation{ModuleId: e.ModuleId, CommandId: e.CommandId, ArgumentId: id, Value: val, Err: ErrArgumentUnknown})
+		}
+	}
+	return
+}
@@ -0,0 +1,116 @@
+package telematics
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/boiledgas/protocol/telematics/section"
+)
+
+func Test_Validator(t *testing.T) {
+	conf := testConfiguration()
+	req := Request{}
+	req.Values = []section.ModulePropertyValue{{
+		ModuleId: 3,
+		Values:   map[byte]interface{}{1: 2 * time.Hour, 2: float32(40), 3: byte(2), 4: byte(1)},
+	}}
+	req.Set(section.FLAG_MODULE_PROPERTY_VALUE, true)
+	req.Executes = []section.CommandExecute{{ModuleId: 2, CommandId: 5, Arguments: map[byte]interface{}{1: uint16(30), 9: byte(1)}}}
+	req.Set(section.FLAG_COMMAND_EXECUTE, true)
+
+	v := Validator{Configuration: &conf}
+	violations := v.Validate(&req)
+	expected := []error{ErrArgumentRequired, ErrArgumentUnknown, section.ErrAboveMax, section.ErrNotInList, ErrPropertyNotReadable}
+	if len(violations) != len(expected) {
+		t.Fatalf("violations wrong: %v", violations)
+	}
+	for i, err := range expected {
+		if !errors.Is(violations[i], err) {
+			t.Errorf("violation %v wrong: %v != %v", i, violations[i], err)
+		}
+	}
+	if violations[2].ModuleId != 3 || violations[2].PropertyId != 1 || violations[2].Value != 2*time.Hour {
+		t.Errorf("violation ids wrong: %v", violations[2])
+	}
+
+	quarantined := v.Quarantine(&req)
+	if len(quarantined) != 3 || len(req.Values[0].Values) != 1 {
+		t.Errorf("quarantine wrong: %v %v", quarantined, req.Values)
+	}
+}
+
+func Test_ReaderValidate(t *testing.T) {
+	conf := testConfiguration()
+	req := Request{}
+	req.Values = []section.ModulePropertyValue{{ModuleId: 3, Values: map[byte]interface{}{3: byte(5)}}}
+	req.Set(section.FLAG_MODULE_PROPERTY_VALUE, true)
+
+	buf := bytes.Buffer{}
+	w := NewWriter(&buf)
+	w.Configuration = &conf
+	w.WriteRequest(&req)
+	data := buf.Bytes()
+
+	r := NewReader(bytes.NewReader(data))
+	r.Configuration = &conf
+	if err := r.ReadRequest(&Request{}); err != nil {
+		t.Errorf("validation is optional: %v", err)
+	}
+	r = NewReader(bytes.NewReader(data))
+	r.Configuration = &conf
+	r.Validate = true
+	res := Request{}
+	err := r.ReadRequest(&res)
+	verr, ok := err.(*ValidationError)
+	if !ok || len(verr.Violations) != 1 || !errors.Is(verr.Violations[0], section.ErrNotInList) {
+		t.Errorf("validation error wrong: %v", err)
+	}
+	if res.Values[0].Values[3] != byte(5) {
+		t.Errorf("request not read: %v", res)
+	}
+}
+
+func Test_ReadConfigurationValidate(t *testing.T) {
+	conf := testConfiguration()
+	req := Request{Sequence: 1, Conf: conf}
+	for _, flag := range []uint16{section.FLAG_MODULE, section.FLAG_MODULE_PROPERTY, section.FLAG_COMMAND, section.FLAG_COMMAND_ARGUMENT} {
+		req.Set(flag, true)
+	}
+	buf := bytes.Buffer{}
+	if err := NewWriter(&buf).WriteRequest(&req); err != nil {
+		t.Fatal(err)
+	}
+	res := Request{}
+	if err := NewReader(&buf).ReadRequest(&res); err != nil {
+		t.Fatal(err)
+	}
+	read := res.Conf
+	if len(read.Properties) != len(conf.Properties) || len(read.Arguments) != len(conf.Arguments) {
+		t.Fatalf("configuration wrong: %v", read)
+	}
+	for i, p := range read.Properties {
+		if !reflect.DeepEqual(p, conf.Properties[i]) {
+			t.Errorf("property wrong: %v != %v", p, conf.Properties[i])
+		}
+	}
+	for i, a := range read.Arguments {
+		if !reflect.DeepEqual(a, conf.Arguments[i]) {
+			t.Errorf("argument wrong: %v != %v", a, conf.Arguments[i])
+		}
+	}
+
+	values := Request{}
+	values.Values = []section.ModulePropertyValue{
+		{ModuleId: 1, Values: map[byte]interface{}{2: float32(90), 3: byte(3)}},
+		{ModuleId: 3, Values: map[byte]interface{}{1: time.Minute}},
+	}
+	values.Set(section.FLAG_MODULE_PROPERTY_VALUE, true)
+	v := Validator{Configuration: &read}
+	violations := v.Validate(&values)
+	if len(violations) != 1 || !errors.Is(violations[0], section.ErrAboveMax) || violations[0].PropertyId != 2 {
+		t.Errorf("violations wrong: %v", violations)
+	}
+}
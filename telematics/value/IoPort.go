@@ -1,7 +1,116 @@
 package value
 
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// pins of one io port, wire format carries one byte of directions and one of levels,
+// ports of more than 8 pins are not supported
+const IOPORT_PINS byte = 8
+
+var ErrPinRange = errors.New("io pin out of range")
+
+type PinMode byte
+
+// pin mode
+const (
+	PIN_MODE_INPUT  PinMode = 0x00
+	PIN_MODE_OUTPUT PinMode = 0x01
+)
+
+func (m PinMode) String() string {
+	switch m {
+	case PIN_MODE_INPUT:
+		return "In"
+	case PIN_MODE_OUTPUT:
+		return "Out"
+	}
+	return ""
+}
+
+type Pin struct {
+	Number byte
+	Mode   PinMode
+	High   bool
+}
+
+func (p Pin) IsOutput() bool {
+	return p.Mode == PIN_MODE_OUTPUT
+}
+
+func (p Pin) IsInput() bool {
+	return p.Mode == PIN_MODE_INPUT
+}
+
+func (p Pin) IsHigh() bool {
+	return p.High
+}
+
+func (p Pin) IsLow() bool {
+	return !p.High
+}
+
+func (p Pin) String() string {
+	level := 0
+	if p.High {
+		level = 1
+	}
+	return fmt.Sprintf("%v:%v=%v", p.Number, p.Mode, level)
+}
+
+// Flags holds pin directions (bit set - output), State holds pin levels (bit set - high)
 type IoPort struct {
 	Flags byte
 	State byte
 }
 
+// Pin returns pin n, false if port has no such pin
+func (v IoPort) Pin(n byte) (Pin, bool) {
+	if n >= IOPORT_PINS {
+		return Pin{}, false
+	}
+	mask := byte(1 << n)
+	p := Pin{Number: n, Mode: PIN_MODE_INPUT, High: v.State&mask > 0}
+	if v.Flags&mask > 0 {
+		p.Mode = PIN_MODE_OUTPUT
+	}
+	return p, true
+}
+
+// SetPin sets direction and level of pin, ErrPinRange is returned if port has no such pin
+func (v *IoPort) SetPin(p Pin) error {
+	if p.Number >= IOPORT_PINS {
+		return fmt.Errorf("%w: %v", ErrPinRange, p.Number)
+	}
+	mask := byte(1 << p.Number)
+	v.Flags &= ^mask
+	if p.Mode == PIN_MODE_OUTPUT {
+		v.Flags |= mask
+	}
+	v.State &= ^mask
+	if p.High {
+		v.State |= mask
+	}
+	return nil
+}
+
+func (v IoPort) Pins() []Pin {
+	pins := make([]Pin, 0, IOPORT_PINS)
+	for n := byte(0); n < IOPORT_PINS; n++ {
+		p, _ := v.Pin(n)
+		pins = append(pins, p)
+	}
+	return pins
+}
+
+func (v IoPort) String() string {
+	var buf bytes.Buffer
+	buf.WriteString("IoPort {")
+	for _, p := range v.Pins() {
+		buf.WriteString(fmt.Sprintf("%v; ", p))
+	}
+	buf.WriteString("}")
+	return buf.String()
+}
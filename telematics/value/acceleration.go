@@ -1,18 +1,43 @@
 package value
 
-import "github.com/boiledgas/protocol/utils"
+import (
+	"github.com/boiledgas/protocol/utils"
+	"math"
+)
 
 const (
 	ACCELERATION_FLAG_X        byte = 0x01
 	ACCELERATION_FLAG_Y        byte = 0x02
 	ACCELERATION_FLAG_Z        byte = 0x04
 	ACCELERATION_FLAG_DURATION byte = 0x08
+	ACCELERATION_FLAG_GYRO_X   byte = 0x10
+	ACCELERATION_FLAG_GYRO_Y   byte = 0x20
+	ACCELERATION_FLAG_GYRO_Z   byte = 0x40
 )
 
+// axes in g, gyroscope axes (angular rate) in degrees per second
 type Acceleration struct {
 	utils.Flags8
 	AxisX    float32
 	AxisY    float32
 	AxisZ    float32
 	Duration uint16
+	GyroX    float32
+	GyroY    float32
+	GyroZ    float32
+}
+
+// GForce returns magnitude of acceleration vector over axes present
+func (v Acceleration) GForce() float64 {
+	var sum float64
+	if v.Has(ACCELERATION_FLAG_X) {
+		sum += float64(v.AxisX) * float64(v.AxisX)
+	}
+	if v.Has(ACCELERATION_FLAG_Y) {
+		sum += float64(v.AxisY) * float64(v.AxisY)
+	}
+	if v.Has(ACCELERATION_FLAG_Z) {
+		sum += float64(v.AxisZ) * float64(v.AxisZ)
+	}
+	return math.Sqrt(sum)
 }
@@ -0,0 +1,69 @@
+package value
+
+import (
+	"bytes"
+	"fmt"
+)
+
+type RadioType byte
+
+// radio access technology
+const (
+	RADIO_GSM   RadioType = 0x01
+	RADIO_UMTS  RadioType = 0x02
+	RADIO_LTE   RadioType = 0x03
+	RADIO_NBIOT RadioType = 0x04
+)
+
+func (t RadioType) String() string {
+	switch t {
+	case RADIO_GSM:
+		return "GSM"
+	case RADIO_UMTS:
+		return "UMTS"
+	case RADIO_LTE:
+		return "LTE"
+	case RADIO_NBIOT:
+		return "NB-IoT"
+	}
+	return ""
+}
+
+// HasTAC reports whether cell area is tracking area (LTE, NB-IoT) rather than location area
+func (t RadioType) HasTAC() bool {
+	return t == RADIO_LTE || t == RADIO_NBIOT
+}
+
+type Cell struct {
+	Radio  RadioType
+	LAC    uint16 // GSM, UMTS
+	TAC    uint16 // LTE, NB-IoT
+	CID    uint32 // 28-bit E-UTRAN cell id for LTE
+	Signal int8
+}
+
+func (c Cell) String() string {
+	area := fmt.Sprintf("LAC:%v", c.LAC)
+	if c.Radio.HasTAC() {
+		area = fmt.Sprintf("TAC:%v", c.TAC)
+	}
+	return fmt.Sprintf("{%v; %v; CID:%v; Signal:%v}", c.Radio, area, c.CID, c.Signal)
+}
+
+// serving and neighbor cells of one network
+type CellInfo struct {
+	MCC       string
+	MNC       string
+	Serving   Cell
+	Neighbors []Cell
+}
+
+func (v CellInfo) String() string {
+	var buf bytes.Buffer
+	buf.WriteString(fmt.Sprintf("MCC:%v; MNC:%v; Serving:%v; Neighbors:[", v.MCC, v.MNC, v.Serving))
+	for _, c := range v.Neighbors {
+		buf.WriteString(c.String())
+	}
+	buf.WriteString("]")
+	return buf.String()
+}
@@ -0,0 +1,142 @@
+package value
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/boiledgas/protocol/utils"
+)
+
+// Convert returns v as go type of data type t (see Zero), numeric values are converted with range check
+func Convert(t DataType, v interface{}) (interface{}, error) {
+	zero := Zero(t)
+	if zero == nil {
+		return nil, fmt.Errorf("data type %v not supported", t)
+	}
+	if reflect.TypeOf(zero) == reflect.TypeOf(v) {
+		return v, nil
+	}
+	rv := reflect.ValueOf(v)
+	target := reflect.TypeOf(zero)
+	if !rv.IsValid() || !isNumber(rv.Kind()) || !isNumber(target.Kind()) {
+		return nil, fmt.Errorf("value %v (%T) is not %v", v, v, t)
+	}
+	res := reflect.New(target).Elem()
+	switch target.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, ok := toInt64(rv)
+		if !ok || res.OverflowInt(i) {
+			return nil, fmt.Errorf("value %v is not %v: %v", v, t, utils.ErrOverflow)
+		}
+		res.SetInt(i)
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var u uint64
+		ok := true
+		if rv.Kind() >= reflect.Uint && rv.Kind() <= reflect.Uint64 {
+			u = rv.Uint()
+		} else {
+			var i int64
+			i, ok = toInt64(rv)
+			ok, u = ok && i >= 0, uint64(i)
+		}
+		if !ok || res.OverflowUint(u) {
+			return nil, fmt.Errorf("value %v is not %v: %v", v, t, utils.ErrOverflow)
+		}
+		res.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f := toFloat64(rv)
+		if res.OverflowFloat(f) {
+			return nil, fmt.Errorf("value %v is not %v: %v", v, t, utils.ErrOverflow)
+		}
+		res.SetFloat(f)
+	}
+	return res.Interface(), nil
+}
+
+// Compare returns -1, 0 or 1 comparing a and b of same go type, composite values are not ordered
+func Compare(a interface{}, b interface{}) (int, error) {
+	if reflect.TypeOf(a) != reflect.TypeOf(b) {
+		return 0, fmt.Errorf("values %v (%T) and %v (%T) are different types", a, a, b, b)
+	}
+	switch a := a.(type) {
+	case time.Time:
+		return compareInt(a.UnixNano(), b.(time.Time).UnixNano()), nil
+	case string:
+		return strings.Compare(a, b.(string)), nil
+	}
+	ra, rb := reflect.ValueOf(a), reflect.ValueOf(b)
+	switch ra.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return compareInt(ra.Int(), rb.Int()), nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return compareUint(ra.Uint(), rb.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return compareFloat(ra.Float(), rb.Float()), nil
+	}
+	return 0, fmt.Errorf("values of %T are not ordered", a)
+}
+
+func isNumber(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Float64 && k != reflect.Uintptr
+}
+
+// integer part of rv, false for fractional or infinite values
+func toInt64(rv reflect.Value) (int64, bool) {
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u := rv.Uint()
+		return int64(u), u <= math.MaxInt64
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+			return 0, false
+		}
+		return int64(f), true
+	}
+	return 0, false
+}
+
+func toFloat64(rv reflect.Value) float64 {
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint())
+	}
+	return rv.Float()
+}
+
+func compareInt(a int64, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareUint(a uint64, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareFloat(a float64, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
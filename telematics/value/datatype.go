@@ -68,6 +68,7 @@ const (
 	Illuminance  DataType = 0x3E
 	Radiation    DataType = 0x3F
 	RGB          DataType = 0x41
+	CELLINFO     DataType = 0x42
 )
 
 func (t DataType) String() string {
@@ -202,6 +203,8 @@ func (t DataType) String() string {
 		return "Radiation"
 	case RGB:
 		return "RGB"
+	case CELLINFO:
+		return "CELLINFO"
 	}
 	return ""
 }
@@ -13,6 +13,54 @@ const (
 	GPS_FLAG_SPEED      byte = 0x04
 	GPS_FLAG_COURSE     byte = 0x08
 	GPS_FLAG_SATELLITES byte = 0x10
+	GPS_FLAG_DOP        byte = 0x20
+	GPS_FLAG_FIX        byte = 0x40
+	GPS_FLAG_EXTENDED   byte = 0x80 // extended flags byte follows
+)
+
+// gpsData extended flags
+const (
+	GPS_EXTFLAG_SPEED  byte = 0x01 // speed with 0.01 km/h resolution
+	GPS_EXTFLAG_COURSE byte = 0x02 // course with 0.01 degree resolution
+)
+
+type GpsFix byte
+
+// gps fix type
+const (
+	GPS_FIX_NONE GpsFix = 0x00
+	GPS_FIX_2D   GpsFix = 0x01
+	GPS_FIX_3D   GpsFix = 0x02
+	GPS_FIX_DGPS GpsFix = 0x03
+	GPS_FIX_RTK  GpsFix = 0x04
+)
+
+func (f GpsFix) String() string {
+	switch f {
+	case GPS_FIX_NONE:
+		return "None"
+	case GPS_FIX_2D:
+		return "2D"
+	case GPS_FIX_3D:
+		return "3D"
+	case GPS_FIX_DGPS:
+		return "DGPS"
+	case GPS_FIX_RTK:
+		return "RTK"
+	}
+	return ""
+}
+
+type Gnss byte
+
+// gnss constellations, combined as bitmask
+const (
+	GNSS_GPS     Gnss = 0x01
+	GNSS_GLONASS Gnss = 0x02
+	GNSS_GALILEO Gnss = 0x04
+	GNSS_BEIDOU  Gnss = 0x08
+	GNSS_QZSS    Gnss = 0x10
+	GNSS_SBAS    Gnss = 0x20
 )
 
 type Gps struct {
@@ -23,6 +71,16 @@ type Gps struct {
 	Speed     byte
 	Course    byte
 	Sat       byte
+
+	Hdop          float32
+	Pdop          float32
+	Fix           GpsFix
+	Valid         bool
+	Constellation Gnss
+
+	Extended      utils.Flags8
+	PreciseSpeed  float32 // km/h
+	PreciseCourse float32 // degrees
 }
 
 func (v Gps) String() string {
@@ -42,5 +100,19 @@ func (v Gps) String() string {
 	if v.Has(GPS_FLAG_SATELLITES) {
 		buf.WriteString(fmt.Sprintf("Sat:%v; ", v.Sat))
 	}
+	if v.Has(GPS_FLAG_DOP) {
+		buf.WriteString(fmt.Sprintf("Hdop:%v; Pdop:%v; ", v.Hdop, v.Pdop))
+	}
+	if v.Has(GPS_FLAG_FIX) {
+		buf.WriteString(fmt.Sprintf("Fix:%v; Valid:%v; Gnss:%02X; ", v.Fix, v.Valid, byte(v.Constellation)))
+	}
+	if v.Has(GPS_FLAG_EXTENDED) {
+		if v.Extended.Has(GPS_EXTFLAG_SPEED) {
+			buf.WriteString(fmt.Sprintf("PreciseSpeed:%v; ", v.PreciseSpeed))
+		}
+		if v.Extended.Has(GPS_EXTFLAG_COURSE) {
+			buf.WriteString(fmt.Sprintf("PreciseCourse:%v; ", v.PreciseCourse))
+		}
+	}
 	return buf.String()
 }
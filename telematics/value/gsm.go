@@ -1,5 +1,10 @@
 package value
 
+import (
+	"errors"
+	"strconv"
+)
+
 type Gsm struct {
 	MCC    string
 	MNC    string
@@ -7,3 +12,35 @@ type Gsm struct {
 	CID    uint16
 	Signal int8
 }
+
+// CellInfo converts legacy gsm value to serving cell info
+func (v Gsm) CellInfo() CellInfo {
+	return CellInfo{
+		MCC:     v.MCC,
+		MNC:     v.MNC,
+		Serving: Cell{Radio: RADIO_GSM, LAC: v.LAC, CID: uint32(v.CID), Signal: v.Signal},
+	}
+}
+
+// MccMnc packs mcc and mnc into decimal digits of 24-bit wire value, mnc length is kept by digit count
+func MccMnc(mcc string, mnc string) (uint32, error) {
+	if len(mcc) != 3 || len(mnc) < 2 || len(mnc) > 3 {
+		return 0, errors.New("mcc must have 3 digits and mnc 2 or 3 digits")
+	}
+	v, err := strconv.ParseUint(mcc+mnc, 10, 24)
+	if err != nil {
+		return 0, err
+	}
+	return uint32(v), nil
+}
+
+// SplitMccMnc unpacks 24-bit wire value, mnc keeps its leading zeros ("01" and "001" are different networks)
+func SplitMccMnc(v uint32) (mcc string, mnc string) {
+	s := strconv.FormatUint(uint64(v), 10)
+	if len(s) < 3 {
+		mcc, mnc = s, "0"
+		return
+	}
+	mcc, mnc = s[:3], s[3:]
+	return
+}
@@ -0,0 +1,525 @@
+package value
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/boiledgas/protocol/utils"
+)
+
+var dataTypes map[string]DataType
+
+func init() {
+	dataTypes = make(map[string]DataType)
+	for i := 0; i < 256; i++ {
+		t := DataType(i)
+		if name := t.String(); name != "" {
+			dataTypes[name] = t
+		}
+	}
+}
+
+func ParseDataType(name string) (DataType, error) {
+	if t, ok := dataTypes[name]; ok {
+		return t, nil
+	}
+	return NotSet, fmt.Errorf("unknown data type %v", name)
+}
+
+func (t DataType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+func (t *DataType) UnmarshalJSON(data []byte) (err error) {
+	var name string
+	if err = json.Unmarshal(data, &name); err != nil {
+		return
+	}
+	*t, err = ParseDataType(name)
+	return
+}
+
+// TypeOf returns data type carried by go type of v, semantic types (Temperature, Voltage...) map to their storage type
+func TypeOf(v interface{}) DataType {
+	switch v.(type) {
+	case bool:
+		return Bool
+	case int8:
+		return SByte
+	case byte:
+		return Byte
+	case int16:
+		return Short
+	case uint16:
+		return UShort
+	case int32:
+		return Int
+	case uint32:
+		return UInt
+	case int64:
+		return Long
+	case uint64:
+		return ULong
+	case float32:
+		return Float
+	case float64:
+		return Double
+	case string:
+		return String
+	case []byte:
+		return Binary
+	case time.Time:
+		return Timestamp
+	case time.Duration:
+		return Timespan
+	case Common:
+		return COMMON
+	case IoPort:
+		return IOPort
+	case Gps:
+		return GPS
+	case Gsm:
+		return GSM
+	case CellInfo:
+		return CELLINFO
+	case Acceleration:
+		return ACCELERATION
+	case Rgb:
+		return RGB
+	}
+	return NotSet
+}
+
+// Zero returns zero value of go type used for data type t
+func Zero(t DataType) interface{} {
+	switch t {
+	case Bool, OpenClose, OnOff, YesNo, IOPin, Tamper, Break, Ignition, Movement, Alarm, Panic, Smoke:
+		return false
+	case SByte:
+		return int8(0)
+	case Byte, Loudness:
+		return byte(0)
+	case Short:
+		return int16(0)
+	case UShort:
+		return uint16(0)
+	case Int24, Int, Rpm:
+		return int32(0)
+	case UInt24, UInt, Frequency, EngineHours:
+		return uint32(0)
+	case Long:
+		return int64(0)
+	case ULong:
+		return uint64(0)
+	case Float, Temperature, Humidity, Pressure, Weight, Angle, Speed:
+		return float32(0)
+	case Double, Analog, Mileage, Distance:
+		return float64(0)
+	case String:
+		return ""
+	case Binary, Identify:
+		return []byte(nil)
+	case Timestamp:
+		return time.Time{}
+	case Timespan:
+		return time.Duration(0)
+	case COMMON, Voltage, Battery, Power, Liquid, Water, Fuel, Gas, Illuminance, Radiation:
+		return Common{}
+	case IOPort:
+		return IoPort{}
+	case GPS:
+		return Gps{}
+	case GSM:
+		return Gsm{}
+	case CELLINFO:
+		return CellInfo{}
+	case ACCELERATION:
+		return Acceleration{}
+	case RGB:
+		return Rgb{}
+	}
+	return nil
+}
+
+func MarshalData(t DataType, v interface{}) (json.RawMessage, error) {
+	if zero := Zero(t); zero == nil || reflect.TypeOf(zero) != reflect.TypeOf(v) {
+		return nil, fmt.Errorf("value %v (%T) is not %v", v, v, t)
+	}
+	if d, ok := v.(time.Duration); ok {
+		return json.Marshal(d.String())
+	}
+	return json.Marshal(v)
+}
+
+func UnmarshalData(t DataType, data []byte) (interface{}, error) {
+	zero := Zero(t)
+	if zero == nil {
+		return nil, fmt.Errorf("data type %v not supported", t)
+	}
+	if _, ok := zero.(time.Duration); ok {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, err
+		}
+		return time.ParseDuration(s)
+	}
+	p := reflect.New(reflect.TypeOf(zero))
+	if err := json.Unmarshal(data, p.Interface()); err != nil {
+		return nil, err
+	}
+	return p.Elem().Interface(), nil
+}
+
+// typed value for text encodings
+type TypedJSON struct {
+	Id    byte            `json:"id"`
+	Type  DataType        `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+var commonFlags = []utils.FlagName{
+	{Flag: uint16(COMMON_FLAG_STATE), Name: "state"},
+	{Flag: uint16(COMMON_FLAG_PERCENTAGE), Name: "percentage"},
+	{Flag: uint16(COMMON_FLAG_VALUE), Name: "value"},
+	{Flag: uint16(COMMON_FLAG_METER), Name: "meter"},
+}
+
+type commonJSON struct {
+	Flags      map[string]bool `json:"flags"`
+	State      bool            `json:"state,omitempty"`
+	Percentage byte            `json:"percentage,omitempty"`
+	Value      float64         `json:"value,omitempty"`
+	Meter      float64         `json:"meter,omitempty"`
+}
+
+func (v Common) MarshalJSON() ([]byte, error) {
+	return json.Marshal(commonJSON{
+		Flags:      v.Named(commonFlags),
+		State:      v.State,
+		Percentage: v.Percentage,
+		Value:      v.Value,
+		Meter:      v.Meter,
+	})
+}
+
+func (v *Common) UnmarshalJSON(data []byte) error {
+	var j commonJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	*v = Common{State: j.State, Percentage: j.Percentage, Value: j.Value, Meter: j.Meter}
+	return v.SetNamed(commonFlags, j.Flags)
+}
+
+var gpsFlags = []utils.FlagName{
+	{Flag: uint16(GPS_FLAG_LATLNG), Name: "latlng"},
+	{Flag: uint16(GPS_FLAG_ALTITUDE), Name: "altitude"},
+	{Flag: uint16(GPS_FLAG_SPEED), Name: "speed"},
+	{Flag: uint16(GPS_FLAG_COURSE), Name: "course"},
+	{Flag: uint16(GPS_FLAG_SATELLITES), Name: "satellites"},
+	{Flag: uint16(GPS_FLAG_DOP), Name: "dop"},
+	{Flag: uint16(GPS_FLAG_FIX), Name: "fix"},
+	{Flag: uint16(GPS_FLAG_EXTENDED), Name: "extended"},
+}
+
+var gpsExtendedFlags = []utils.FlagName{
+	{Flag: uint16(GPS_EXTFLAG_SPEED), Name: "preciseSpeed"},
+	{Flag: uint16(GPS_EXTFLAG_COURSE), Name: "preciseCourse"},
+}
+
+type gpsJSON struct {
+	Flags         map[string]bool `json:"flags"`
+	Extended      map[string]bool `json:"extended,omitempty"`
+	Latitude      float64         `json:"latitude,omitempty"`
+	Longitude     float64         `json:"longitude,omitempty"`
+	Altitude      int16           `json:"altitude,omitempty"`
+	Speed         byte            `json:"speed,omitempty"`
+	Course        byte            `json:"course,omitempty"`
+	Sat           byte            `json:"satellites,omitempty"`
+	Hdop          float32         `json:"hdop,omitempty"`
+	Pdop          float32         `json:"pdop,omitempty"`
+	Fix           GpsFix          `json:"fix,omitempty"`
+	Valid         bool            `json:"valid,omitempty"`
+	Constellation Gnss            `json:"constellation,omitempty"`
+	PreciseSpeed  float32         `json:"preciseSpeed,omitempty"`
+	PreciseCourse float32         `json:"preciseCourse,omitempty"`
+}
+
+func (v Gps) MarshalJSON() ([]byte, error) {
+	j := gpsJSON{
+		Flags:         v.Named(gpsFlags),
+		Latitude:      v.Latitude,
+		Longitude:     v.Longitude,
+		Altitude:      v.Altitude,
+		Speed:         v.Speed,
+		Course:        v.Course,
+		Sat:           v.Sat,
+		Hdop:          v.Hdop,
+		Pdop:          v.Pdop,
+		Fix:           v.Fix,
+		Valid:         v.Valid,
+		Constellation: v.Constellation,
+		PreciseSpeed:  v.PreciseSpeed,
+		PreciseCourse: v.PreciseCourse,
+	}
+	if v.Has(GPS_FLAG_EXTENDED) {
+		j.Extended = v.Extended.Named(gpsExtendedFlags)
+	}
+	return json.Marshal(j)
+}
+
+func (v *Gps) UnmarshalJSON(data []byte) error {
+	var j gpsJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	*v = Gps{
+		Latitude:      j.Latitude,
+		Longitude:     j.Longitude,
+		Altitude:      j.Altitude,
+		Speed:         j.Speed,
+		Course:        j.Course,
+		Sat:           j.Sat,
+		Hdop:          j.Hdop,
+		Pdop:          j.Pdop,
+		Fix:           j.Fix,
+		Valid:         j.Valid,
+		Constellation: j.Constellation,
+		PreciseSpeed:  j.PreciseSpeed,
+		PreciseCourse: j.PreciseCourse,
+	}
+	if err := v.Extended.SetNamed(gpsExtendedFlags, j.Extended); err != nil {
+		return err
+	}
+	return v.SetNamed(gpsFlags, j.Flags)
+}
+
+// MarshalJSON writes name of fix quality, unknown fix quality is written as number
+func (f GpsFix) MarshalJSON() ([]byte, error) {
+	if name := f.String(); name != "" {
+		return json.Marshal(name)
+	}
+	return json.Marshal(byte(f))
+}
+
+func (f *GpsFix) UnmarshalJSON(data []byte) error {
+	var number byte
+	if json.Unmarshal(data, &number) == nil {
+		*f = GpsFix(number)
+		return nil
+	}
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	for fix := GPS_FIX_NONE; fix <= GPS_FIX_RTK; fix++ {
+		if fix.String() == name {
+			*f = fix
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown gps fix %v", name)
+}
+
+var accelerationFlags = []utils.FlagName{
+	{Flag: uint16(ACCELERATION_FLAG_X), Name: "x"},
+	{Flag: uint16(ACCELERATION_FLAG_Y), Name: "y"},
+	{Flag: uint16(ACCELERATION_FLAG_Z), Name: "z"},
+	{Flag: uint16(ACCELERATION_FLAG_DURATION), Name: "duration"},
+	{Flag: uint16(ACCELERATION_FLAG_GYRO_X), Name: "gyroX"},
+	{Flag: uint16(ACCELERATION_FLAG_GYRO_Y), Name: "gyroY"},
+	{Flag: uint16(ACCELERATION_FLAG_GYRO_Z), Name: "gyroZ"},
+}
+
+type accelerationJSON struct {
+	Flags    map[string]bool `json:"flags"`
+	AxisX    float32         `json:"x,omitempty"`
+	AxisY    float32         `json:"y,omitempty"`
+	AxisZ    float32         `json:"z,omitempty"`
+	Duration uint16          `json:"duration,omitempty"`
+	GyroX    float32         `json:"gyroX,omitempty"`
+	GyroY    float32         `json:"gyroY,omitempty"`
+	GyroZ    float32         `json:"gyroZ,omitempty"`
+}
+
+func (v Acceleration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(accelerationJSON{
+		Flags:    v.Named(accelerationFlags),
+		AxisX:    v.AxisX,
+		AxisY:    v.AxisY,
+		AxisZ:    v.AxisZ,
+		Duration: v.Duration,
+		GyroX:    v.GyroX,
+		GyroY:    v.GyroY,
+		GyroZ:    v.GyroZ,
+	})
+}
+
+func (v *Acceleration) UnmarshalJSON(data []byte) error {
+	var j accelerationJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	*v = Acceleration{
+		AxisX:    j.AxisX,
+		AxisY:    j.AxisY,
+		AxisZ:    j.AxisZ,
+		Duration: j.Duration,
+		GyroX:    j.GyroX,
+		GyroY:    j.GyroY,
+		GyroZ:    j.GyroZ,
+	}
+	return v.SetNamed(accelerationFlags, j.Flags)
+}
+
+type pinJSON struct {
+	Number byte `json:"number"`
+	Output bool `json:"output"`
+	High   bool `json:"high"`
+}
+
+func (v IoPort) MarshalJSON() ([]byte, error) {
+	var pins []pinJSON
+	for _, p := range v.Pins() {
+		pins = append(pins, pinJSON{Number: p.Number, Output: p.IsOutput(), High: p.High})
+	}
+	return json.Marshal(pins)
+}
+
+func (v *IoPort) UnmarshalJSON(data []byte) error {
+	var pins []pinJSON
+	if err := json.Unmarshal(data, &pins); err != nil {
+		return err
+	}
+	*v = IoPort{}
+	for _, p := range pins {
+		pin := Pin{Number: p.Number, Mode: PIN_MODE_INPUT, High: p.High}
+		if p.Output {
+			pin.Mode = PIN_MODE_OUTPUT
+		}
+		if err := v.SetPin(pin); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type gsmJSON struct {
+	MCC    string `json:"mcc"`
+	MNC    string `json:"mnc"`
+	LAC    uint16 `json:"lac"`
+	CID    uint16 `json:"cid"`
+	Signal int8   `json:"signal"`
+}
+
+func (v Gsm) MarshalJSON() ([]byte, error) {
+	return json.Marshal(gsmJSON(v))
+}
+
+func (v *Gsm) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*gsmJSON)(v))
+}
+
+type cellJSON struct {
+	Radio  RadioType `json:"radio"`
+	LAC    uint16    `json:"lac,omitempty"`
+	TAC    uint16    `json:"tac,omitempty"`
+	CID    uint32    `json:"cid"`
+	Signal int8      `json:"signal"`
+}
+
+func (c Cell) MarshalJSON() ([]byte, error) {
+	return json.Marshal(cellJSON(c))
+}
+
+func (c *Cell) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*cellJSON)(c))
+}
+
+type cellInfoJSON struct {
+	MCC       string `json:"mcc"`
+	MNC       string `json:"mnc"`
+	Serving   Cell   `json:"serving"`
+	Neighbors []Cell `json:"neighbors,omitempty"`
+}
+
+func (v CellInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(cellInfoJSON(v))
+}
+
+func (v *CellInfo) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*cellInfoJSON)(v))
+}
+
+// MarshalJSON writes name of radio type, unknown or not set radio type is written as number
+func (t RadioType) MarshalJSON() ([]byte, error) {
+	if name := t.String(); name != "" {
+		return json.Marshal(name)
+	}
+	return json.Marshal(byte(t))
+}
+
+func (t *RadioType) UnmarshalJSON(data []byte) error {
+	var number byte
+	if json.Unmarshal(data, &number) == nil {
+		*t = RadioType(number)
+		return nil
+	}
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	for radio := RADIO_GSM; radio <= RADIO_NBIOT; radio++ {
+		if radio.String() == name {
+			*t = radio
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown radio type %v", name)
+}
+
+type rgbJSON struct {
+	R byte `json:"r"`
+	G byte `json:"g"`
+	B byte `json:"b"`
+}
+
+func (v Rgb) MarshalJSON() ([]byte, error) {
+	return json.Marshal(rgbJSON(v))
+}
+
+func (v *Rgb) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*rgbJSON)(v))
+}
+
+type nameValueJSON struct {
+	Name  string          `json:"name"`
+	Value json.RawMessage `json:"value"`
+}
+
+// MarshalJSON of name value, value type comes from owning property or argument
+func (v NameValue) MarshalJSON() ([]byte, error) {
+	data, err := MarshalData(TypeOf(v.Value), v.Value)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(nameValueJSON{Name: v.Name, Value: data})
+}
+
+func UnmarshalNameValues(t DataType, data []byte) ([]NameValue, error) {
+	var list []nameValueJSON
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+	result := make([]NameValue, 0, len(list))
+	for _, nv := range list {
+		v, err := UnmarshalData(t, nv.Value)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, NameValue{Name: nv.Name, Value: v})
+	}
+	return result, nil
+}
@@ -0,0 +1,92 @@
+package value
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Label returns name of list item with value v
+func Label(list []NameValue, v interface{}) (string, bool) {
+	for _, nv := range list {
+		if reflect.DeepEqual(nv.Value, v) {
+			return nv.Name, true
+		}
+	}
+	return "", false
+}
+
+// Lookup returns value of list item with name, names are compared case insensitive
+func Lookup(list []NameValue, name string) (interface{}, bool) {
+	for _, nv := range list {
+		if strings.EqualFold(nv.Name, name) {
+			return nv.Value, true
+		}
+	}
+	return nil, false
+}
+
+// Labels returns names of bitmask list items set in v ordered as list, rest has bits without item
+func Labels(list []NameValue, v interface{}) (names []string, rest uint64, err error) {
+	if rest, err = bits(v); err != nil {
+		return
+	}
+	for _, nv := range list {
+		var b uint64
+		if b, err = bits(nv.Value); err != nil {
+			return
+		}
+		if b != 0 && rest&b == b {
+			names = append(names, nv.Name)
+			rest &^= b
+		}
+	}
+	return
+}
+
+// Mask combines values of bitmask list items into go type of t
+func Mask(t DataType, list []NameValue, names ...string) (interface{}, error) {
+	var mask uint64
+	for _, name := range names {
+		v, ok := Lookup(list, name)
+		if !ok {
+			return nil, fmt.Errorf("label %v not in list", name)
+		}
+		b, err := bits(v)
+		if err != nil {
+			return nil, err
+		}
+		mask |= b
+	}
+	zero := Zero(t)
+	if zero == nil {
+		return nil, fmt.Errorf("data type %v not supported", t)
+	}
+	res := reflect.New(reflect.TypeOf(zero)).Elem()
+	switch res.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		res.SetInt(int64(mask))
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		res.SetUint(mask)
+	default:
+		return nil, fmt.Errorf("data type %v is not integer", t)
+	}
+	return res.Interface(), nil
+}
+
+// IsMask returns true if v has only bits of bitmask list items
+func IsMask(list []NameValue, v interface{}) bool {
+	_, rest, err := Labels(list, v)
+	return err == nil && rest == 0
+}
+
+func bits(v interface{}) (uint64, error) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return uint64(rv.Int()) & (1<<(uint(rv.Type().Bits())) - 1), nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return rv.Uint(), nil
+	}
+	return 0, fmt.Errorf("value %v (%T) is not integer", v, v)
+}
@@ -2,9 +2,14 @@ package telematics
 
 import (
 	"bytes"
+	"errors"
+	"math"
+	"reflect"
 	"testing"
 	"time"
+	"github.com/boiledgas/protocol/telematics/section"
 	"github.com/boiledgas/protocol/telematics/value"
+	"github.com/boiledgas/protocol/utils"
 )
 
 func Test_Bool(t *testing.T) {
@@ -444,7 +449,7 @@ func Test_Pressure(t *testing.T) {
 	val := float32(777.77)
 	w.WriteData(val, dataType)
 	v := r.readData(dataType)
-	if v.(float32) != 700 {
+	if v.(float32) != 800 {
 		t.Fail()
 	}
 }
@@ -488,6 +493,47 @@ func Test_Angle(t *testing.T) {
 	}
 }
 
+func Test_FixedPointRounding(t *testing.T) {
+	buf := bytes.Buffer{}
+	r := TelematicsReader{reader: &buf}
+	w := TelematicsWriter{Writer: &buf}
+	dataType := value.Temperature
+	val := float32(-0.15)
+	w.WriteData(val, dataType)
+	v := r.readData(dataType)
+	if v.(float32) != -0.2 {
+		t.Errorf("rounding wrong: %v", v)
+	}
+}
+
+func Test_FixedPointOverflow(t *testing.T) {
+	w := TelematicsWriter{Writer: &bytes.Buffer{}}
+	if err := w.WriteData(float32(6553.6), value.Speed); !errors.Is(err, utils.ErrOverflow) {
+		t.Errorf("overflow not detected: %v", err)
+	}
+}
+
+func Test_WriteDataPanic(t *testing.T) {
+	w := TelematicsWriter{Writer: &bytes.Buffer{}}
+	defer func() {
+		if rec := recover(); rec == nil {
+			t.Error("wrong value type returned as error")
+		}
+	}()
+	w.WriteData(int32(10), value.Speed)
+}
+
+func Test_FixedPointNaN(t *testing.T) {
+	conf := Configuration{Properties: []section.ModuleProperty{{ModuleId: 1, Id: 1, Type: value.Mileage}}}
+	r := Request{Values: []section.ModulePropertyValue{{ModuleId: 1, Values: map[byte]interface{}{1: math.NaN()}}}}
+	r.Set(section.FLAG_MODULE_PROPERTY_VALUE, true)
+	w := NewWriter(&bytes.Buffer{})
+	w.Configuration = &conf
+	if err := w.WriteRequest(&r); !errors.Is(err, utils.ErrNotFinite) {
+		t.Errorf("NaN not rejected: %v", err)
+	}
+}
+
 func Test_Speed(t *testing.T) {
 	buf := bytes.Buffer{}
 	r := TelematicsReader{reader: &buf}
@@ -522,7 +568,7 @@ func Test_Rpm(t *testing.T) {
 	val := int32(7777)
 	w.WriteData(val, dataType)
 	v := r.readData(dataType)
-	if v.(int32) != 7770 {
+	if v.(int32) != 7780 {
 		t.Fail()
 	}
 }
@@ -560,9 +606,13 @@ func Test_Common(t *testing.T) {
 	dataType := value.COMMON
 	val := value.Common{}
 	val.State = true
+	val.Set(value.COMMON_FLAG_STATE, true)
 	val.Value = 7
+	val.Set(value.COMMON_FLAG_VALUE, true)
 	val.Percentage = 77
+	val.Set(value.COMMON_FLAG_PERCENTAGE, true)
 	val.Meter = 777
+	val.Set(value.COMMON_FLAG_METER, true)
 	w.WriteData(val, dataType)
 	v := r.readData(dataType)
 	if v.(value.Common) != val {
@@ -577,9 +627,13 @@ func Test_Voltage(t *testing.T) {
 	dataType := value.Voltage
 	val := value.Common{}
 	val.State = true
+	val.Set(value.COMMON_FLAG_STATE, true)
 	val.Percentage = 77
+	val.Set(value.COMMON_FLAG_PERCENTAGE, true)
 	val.Value = 7.77
+	val.Set(value.COMMON_FLAG_VALUE, true)
 	val.Meter = 77.77
+	val.Set(value.COMMON_FLAG_METER, true)
 	w.WriteData(val, dataType)
 	v := r.readData(dataType)
 	if v.(value.Common) != val {
@@ -594,9 +648,13 @@ func Test_Battery(t *testing.T) {
 	dataType := value.Battery
 	val := value.Common{}
 	val.State = true
+	val.Set(value.COMMON_FLAG_STATE, true)
 	val.Percentage = 77
+	val.Set(value.COMMON_FLAG_PERCENTAGE, true)
 	val.Value = 7.77
+	val.Set(value.COMMON_FLAG_VALUE, true)
 	val.Meter = 77.77
+	val.Set(value.COMMON_FLAG_METER, true)
 	w.WriteData(val, dataType)
 	v := r.readData(dataType)
 	if v.(value.Common) != val {
@@ -611,9 +669,13 @@ func Test_Power(t *testing.T) {
 	dataType := value.Power
 	val := value.Common{}
 	val.State = true
+	val.Set(value.COMMON_FLAG_STATE, true)
 	val.Percentage = 77
+	val.Set(value.COMMON_FLAG_PERCENTAGE, true)
 	val.Value = 7.77
+	val.Set(value.COMMON_FLAG_VALUE, true)
 	val.Meter = 77.77
+	val.Set(value.COMMON_FLAG_METER, true)
 	w.WriteData(val, dataType)
 	v := r.readData(dataType)
 	if v.(value.Common) != val {
@@ -628,9 +690,13 @@ func Test_Liquid(t *testing.T) {
 	dataType := value.Liquid
 	val := value.Common{}
 	val.State = true
+	val.Set(value.COMMON_FLAG_STATE, true)
 	val.Percentage = 77
+	val.Set(value.COMMON_FLAG_PERCENTAGE, true)
 	val.Value = 7.777
+	val.Set(value.COMMON_FLAG_VALUE, true)
 	val.Meter = 77.7
+	val.Set(value.COMMON_FLAG_METER, true)
 	w.WriteData(val, dataType)
 	v := r.readData(dataType)
 	if v.(value.Common) != val {
@@ -645,9 +711,13 @@ func Test_Water(t *testing.T) {
 	dataType := value.Water
 	val := value.Common{}
 	val.State = true
+	val.Set(value.COMMON_FLAG_STATE, true)
 	val.Percentage = 77
+	val.Set(value.COMMON_FLAG_PERCENTAGE, true)
 	val.Value = 7.777
+	val.Set(value.COMMON_FLAG_VALUE, true)
 	val.Meter = 77.7
+	val.Set(value.COMMON_FLAG_METER, true)
 	w.WriteData(val, dataType)
 	v := r.readData(dataType)
 	if v.(value.Common) != val {
@@ -662,9 +732,13 @@ func Test_Fuel(t *testing.T) {
 	dataType := value.Fuel
 	val := value.Common{}
 	val.State = true
+	val.Set(value.COMMON_FLAG_STATE, true)
 	val.Percentage = 77
+	val.Set(value.COMMON_FLAG_PERCENTAGE, true)
 	val.Value = 7.777
+	val.Set(value.COMMON_FLAG_VALUE, true)
 	val.Meter = 77.7
+	val.Set(value.COMMON_FLAG_METER, true)
 	w.WriteData(val, dataType)
 	v := r.readData(dataType)
 	if v.(value.Common) != val {
@@ -679,9 +753,13 @@ func Test_Gas(t *testing.T) {
 	dataType := value.Gas
 	val := value.Common{}
 	val.State = true
+	val.Set(value.COMMON_FLAG_STATE, true)
 	val.Percentage = 77
+	val.Set(value.COMMON_FLAG_PERCENTAGE, true)
 	val.Value = 7.777
+	val.Set(value.COMMON_FLAG_VALUE, true)
 	val.Meter = 77.777
+	val.Set(value.COMMON_FLAG_METER, true)
 	w.WriteData(val, dataType)
 	v := r.readData(dataType)
 	if v.(value.Common) != val {
@@ -696,8 +774,11 @@ func Test_Illuminance(t *testing.T) {
 	dataType := value.Illuminance
 	val := value.Common{}
 	val.State = true
+	val.Set(value.COMMON_FLAG_STATE, true)
 	val.Percentage = 77
+	val.Set(value.COMMON_FLAG_PERCENTAGE, true)
 	val.Value = 7.77
+	val.Set(value.COMMON_FLAG_VALUE, true)
 	w.WriteData(val, dataType)
 	v := r.readData(dataType)
 	if v.(value.Common) != val {
@@ -739,6 +820,40 @@ func Test_IOPort(t *testing.T) {
 	}
 }
 
+func Test_IOPortPins(t *testing.T) {
+	buf := bytes.Buffer{}
+	r := TelematicsReader{reader: &buf}
+	w := TelematicsWriter{Writer: &buf}
+	dataType := value.IOPort
+	val := value.IoPort{}
+	val.SetPin(value.Pin{Number: 0, Mode: value.PIN_MODE_OUTPUT, High: true})
+	val.SetPin(value.Pin{Number: 3, Mode: value.PIN_MODE_INPUT, High: true})
+	val.SetPin(value.Pin{Number: 7, Mode: value.PIN_MODE_OUTPUT})
+	w.WriteData(val, dataType)
+	v := r.readData(dataType).(value.IoPort)
+	if v.Flags != 0x81 || v.State != 0x09 {
+		t.Errorf("io port wrong: %08b %08b (%v)", v.Flags, v.State, buf.Bytes())
+	}
+	if p, _ := v.Pin(0); !p.IsOutput() || !p.IsHigh() {
+		t.Errorf("pin 0 wrong: %v", p)
+	}
+	if p, _ := v.Pin(3); !p.IsInput() || !p.IsHigh() {
+		t.Errorf("pin 3 wrong: %v", p)
+	}
+	if p, _ := v.Pin(7); !p.IsOutput() || !p.IsLow() {
+		t.Errorf("pin 7 wrong: %v", p)
+	}
+	if p, _ := v.Pin(3); p.String() != "3:In=1" {
+		t.Errorf("pin string wrong: %v", p)
+	}
+	if _, ok := v.Pin(8); ok {
+		t.Error("pin 8 of port returned")
+	}
+	if err := val.SetPin(value.Pin{Number: 8}); !errors.Is(err, value.ErrPinRange) {
+		t.Errorf("pin 8 set: %v", err)
+	}
+}
+
 func Test_Gps(t *testing.T) {
 	buf := bytes.Buffer{}
 	r := TelematicsReader{reader: &buf}
@@ -750,6 +865,11 @@ func Test_Gps(t *testing.T) {
 	val.Speed = (77)
 	val.Course = (180)
 	val.Sat = (77)
+	val.Set(value.GPS_FLAG_LATLNG, true)
+	val.Set(value.GPS_FLAG_ALTITUDE, true)
+	val.Set(value.GPS_FLAG_SPEED, true)
+	val.Set(value.GPS_FLAG_COURSE, true)
+	val.Set(value.GPS_FLAG_SATELLITES, true)
 	w.WriteData(val, dataType)
 	v := r.readData(dataType)
 	if v.(value.Gps) != val {
@@ -757,6 +877,32 @@ func Test_Gps(t *testing.T) {
 	}
 }
 
+func Test_GpsExtended(t *testing.T) {
+	buf := bytes.Buffer{}
+	r := TelematicsReader{reader: &buf}
+	w := TelematicsWriter{Writer: &buf}
+	dataType := value.GPS
+	val := value.Gps{}
+	val.Latitude, val.Longitude = 55.7558, 37.6173
+	val.Set(value.GPS_FLAG_LATLNG, true)
+	val.Hdop, val.Pdop = 0.8, 1.35
+	val.Set(value.GPS_FLAG_DOP, true)
+	val.Fix, val.Valid, val.Constellation = value.GPS_FIX_RTK, true, value.GNSS_GPS|value.GNSS_GLONASS
+	val.Set(value.GPS_FLAG_FIX, true)
+	val.PreciseSpeed, val.PreciseCourse = 87.35, 359.99
+	val.Extended.Set(value.GPS_EXTFLAG_SPEED, true)
+	val.Extended.Set(value.GPS_EXTFLAG_COURSE, true)
+	val.Set(value.GPS_FLAG_EXTENDED, true)
+	w.WriteData(val, dataType)
+	v := r.readData(dataType)
+	if v.(value.Gps) != val {
+		t.Errorf("gps wrong: %v != %v (%v)", v, val, buf.Bytes())
+	}
+	if buf.Len() != 0 {
+		t.Errorf("unread bytes: %v", buf.Bytes())
+	}
+}
+
 func Test_Gsm(t *testing.T) {
 	buf := bytes.Buffer{}
 	r := TelematicsReader{reader: &buf}
@@ -775,6 +921,42 @@ func Test_Gsm(t *testing.T) {
 	}
 }
 
+func Test_GsmMncLeadingZero(t *testing.T) {
+	buf := bytes.Buffer{}
+	r := TelematicsReader{reader: &buf}
+	w := TelematicsWriter{Writer: &buf}
+	dataType := value.GSM
+	for _, mnc := range []string{"01", "001"} {
+		val := value.Gsm{MCC: "250", MNC: mnc, LAC: 7, CID: 77}
+		w.WriteData(val, dataType)
+		v := r.readData(dataType)
+		if v.(value.Gsm) != val {
+			t.Errorf("gsm wrong: %v != %v", v, val)
+		}
+	}
+}
+
+func Test_CellInfo(t *testing.T) {
+	buf := bytes.Buffer{}
+	r := TelematicsReader{reader: &buf}
+	w := TelematicsWriter{Writer: &buf}
+	dataType := value.CELLINFO
+	val := value.CellInfo{
+		MCC:     "310",
+		MNC:     "026",
+		Serving: value.Cell{Radio: value.RADIO_LTE, TAC: 7777, CID: 0x0FFFFFFF, Signal: -97},
+		Neighbors: []value.Cell{
+			{Radio: value.RADIO_LTE, TAC: 7778, CID: 777777, Signal: -105},
+			{Radio: value.RADIO_GSM, LAC: 77, CID: 7777, Signal: -80},
+		},
+	}
+	w.WriteData(val, dataType)
+	v := r.readData(dataType)
+	if !reflect.DeepEqual(v.(value.CellInfo), val) {
+		t.Errorf("cell info wrong: %v != %v (%v)", v, val, buf.Bytes())
+	}
+}
+
 func Test_Acceleration(t *testing.T) {
 	buf := bytes.Buffer{}
 	r := TelematicsReader{reader: &buf}
@@ -785,6 +967,10 @@ func Test_Acceleration(t *testing.T) {
 	val.AxisY = 7.77
 	val.AxisZ = 7.77
 	val.Duration = 777
+	val.Set(value.ACCELERATION_FLAG_X, true)
+	val.Set(value.ACCELERATION_FLAG_Y, true)
+	val.Set(value.ACCELERATION_FLAG_Z, true)
+	val.Set(value.ACCELERATION_FLAG_DURATION, true)
 	w.WriteData(val, dataType)
 	v := r.readData(dataType)
 	if v.(value.Acceleration) != val {
@@ -792,6 +978,30 @@ func Test_Acceleration(t *testing.T) {
 	}
 }
 
+func Test_AccelerationFlags(t *testing.T) {
+	buf := bytes.Buffer{}
+	r := TelematicsReader{reader: &buf}
+	w := TelematicsWriter{Writer: &buf}
+	dataType := value.ACCELERATION
+	val := value.Acceleration{AxisX: 0.6, AxisZ: -0.8, GyroY: -123.4, Duration: 777}
+	val.Set(value.ACCELERATION_FLAG_X, true)
+	val.Set(value.ACCELERATION_FLAG_Z, true)
+	val.Set(value.ACCELERATION_FLAG_GYRO_Y, true)
+	w.WriteData(val, dataType)
+	w.WriteData(byte(77), value.Byte)
+	v := r.readData(dataType).(value.Acceleration)
+	val.Duration = 0
+	if v != val {
+		t.Errorf("acceleration wrong: %v != %v (%v)", v, val, buf.Bytes())
+	}
+	if b := r.readData(value.Byte); b.(byte) != 77 {
+		t.Errorf("frame out of sync: %v", b)
+	}
+	if g := v.GForce(); math.Abs(g-1.0) > 0.0001 {
+		t.Errorf("g-force wrong: %v", g)
+	}
+}
+
 func Test_Rgb(t *testing.T) {
 	buf := bytes.Buffer{}
 	r := TelematicsReader{reader: &buf}
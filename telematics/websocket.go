@@ -0,0 +1,366 @@
+package telematics
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// websocket opcodes
+const (
+	WS_OPCODE_CONTINUATION byte = 0x0
+	WS_OPCODE_TEXT         byte = 0x1
+	WS_OPCODE_BINARY       byte = 0x2
+	WS_OPCODE_CLOSE        byte = 0x8
+	WS_OPCODE_PING         byte = 0x9
+	WS_OPCODE_PONG         byte = 0xA
+)
+
+const wsGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// websocket close codes
+const (
+	WS_CLOSE_NORMAL         uint16 = 1000
+	WS_CLOSE_PROTOCOL_ERROR uint16 = 1002
+	WS_CLOSE_UNSUPPORTED    uint16 = 1003
+)
+
+var (
+	ErrWebSocketText     = errors.New("websocket text messages not supported")
+	ErrWebSocketProtocol = errors.New("websocket protocol error")
+)
+
+// WebSocketHandler upgrades HTTP requests to websocket connections served by Server,
+// packets are carried in binary messages
+type WebSocketHandler struct {
+	Server *Server
+	// CheckOrigin returns true if request of browser page from origin is allowed,
+	// if nil request with Origin header is allowed only if origin host is request host
+	CheckOrigin func(r *http.Request) bool
+}
+
+func (h *WebSocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet || !headerHas(r.Header, "Connection", "upgrade") || !headerHas(r.Header, "Upgrade", "websocket") {
+		http.Error(w, "websocket upgrade required", http.StatusBadRequest)
+		return
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		http.Error(w, "websocket version not supported", http.StatusUpgradeRequired)
+		return
+	}
+	if !h.checkOrigin(r) {
+		http.Error(w, "websocket origin not allowed", http.StatusForbidden)
+		return
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if key == "" {
+		http.Error(w, "websocket key required", http.StatusBadRequest)
+		return
+	}
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "connection can't be upgraded", http.StatusInternalServerError)
+		return
+	}
+	conn, rw, err := hj.Hijack()
+	if err != nil {
+		h.Server.logf("telematics: websocket: %v", err)
+		return
+	}
+	rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: " + wsAccept(key) + "\r\n\r\n")
+	if err = rw.Flush(); err != nil {
+		conn.Close()
+		return
+	}
+	ws := &wsConn{Conn: conn, reader: rw.Reader}
+	defer ws.Close()
+	if err = h.Server.ServeConn(ws); err != nil {
+		h.Server.logf("telematics: %v: %v", r.RemoteAddr, err)
+	}
+}
+
+func (h *WebSocketHandler) checkOrigin(r *http.Request) bool {
+	if h.CheckOrigin != nil {
+		return h.CheckOrigin(r)
+	}
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true // devices are not browsers
+	}
+	u, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(u.Host, r.Host)
+}
+
+func headerHas(h http.Header, name string, token string) bool {
+	for _, v := range h[http.CanonicalHeaderKey(name)] {
+		for _, t := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func wsAccept(key string) string {
+	sum := sha1.Sum([]byte(key + wsGUID))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// DialWebSocket connects to ws or wss url, config is used for wss
+func DialWebSocket(rawurl string, config *tls.Config) (*Client, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, err
+	}
+	host := u.Host
+	var conn net.Conn
+	switch u.Scheme {
+	case "ws":
+		if u.Port() == "" {
+			host += ":80"
+		}
+		conn, err = net.Dial("tcp", host)
+	case "wss":
+		if u.Port() == "" {
+			host += ":443"
+		}
+		conn, err = tls.Dial("tcp", host, config)
+	default:
+		return nil, fmt.Errorf("websocket scheme %v not supported", u.Scheme)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var nonce [16]byte
+	if _, err = io.ReadFull(rand.Reader, nonce[:]); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	key := base64.StdEncoding.EncodeToString(nonce[:])
+	req := &http.Request{Method: http.MethodGet, URL: u, Host: u.Host, Header: http.Header{
+		"Upgrade":               {"websocket"},
+		"Connection":            {"Upgrade"},
+		"Sec-Websocket-Key":     {key},
+		"Sec-Websocket-Version": {"13"},
+	}}
+	reader := bufio.NewReader(conn)
+	if err = req.Write(conn); err == nil {
+		var resp *http.Response
+		if resp, err = http.ReadResponse(reader, req); err == nil {
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusSwitchingProtocols || resp.Header.Get("Sec-WebSocket-Accept") != wsAccept(key) {
+				err = fmt.Errorf("websocket upgrade failed: %v", resp.Status)
+			}
+		}
+	}
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return NewClient(&wsConn{Conn: conn, reader: reader, client: true}), nil
+}
+
+// wsConn reads payload of binary messages as stream and writes each Write as binary message,
+// control frames are answered while reading
+type wsConn struct {
+	net.Conn
+	reader *bufio.Reader
+	client bool // client masks frames, server requires masked frames
+
+	remaining  uint64 // payload of current frame not read
+	masked     bool
+	mask       [4]byte
+	pos        int
+	fragmented bool // message is not finished, continuation frame follows
+
+	mutex  sync.Mutex // serializes frame writes
+	closed bool       // close frame sent
+}
+
+func (c *wsConn) Read(p []byte) (n int, err error) {
+	for c.remaining == 0 {
+		if err = c.nextFrame(); err != nil {
+			return
+		}
+	}
+	if uint64(len(p)) > c.remaining {
+		p = p[:c.remaining]
+	}
+	n, err = c.reader.Read(p)
+	c.unmask(p[:n])
+	c.remaining -= uint64(n)
+	return
+}
+
+func (c *wsConn) unmask(p []byte) {
+	if !c.masked {
+		return
+	}
+	for i := range p {
+		p[i] ^= c.mask[c.pos%4]
+		c.pos++
+	}
+}
+
+// nextFrame reads header of data frame, control frames are handled.
+// Frame violating protocol is answered by close frame with protocol error
+func (c *wsConn) nextFrame() error {
+	var header [2]byte
+	if _, err := io.ReadFull(c.reader, header[:]); err != nil {
+		return err
+	}
+	fin := header[0]&0x80 != 0
+	opcode := header[0] & 0x0f
+	if header[0]&0x70 != 0 {
+		return c.fail(WS_CLOSE_PROTOCOL_ERROR, "reserved bits set")
+	}
+	c.masked = header[1]&0x80 != 0
+	length := uint64(header[1] & 0x7f)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.reader, ext[:]); err != nil {
+			return err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.reader, ext[:]); err != nil {
+			return err
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	if !c.client && !c.masked {
+		return c.fail(WS_CLOSE_PROTOCOL_ERROR, "frame of client not masked")
+	}
+	if c.masked {
+		if _, err := io.ReadFull(c.reader, c.mask[:]); err != nil {
+			return err
+		}
+	}
+	c.pos = 0
+	switch opcode {
+	case WS_OPCODE_CONTINUATION:
+		if !c.fragmented {
+			return c.fail(WS_CLOSE_PROTOCOL_ERROR, "continuation frame without message")
+		}
+		c.fragmented, c.remaining = !fin, length
+		return nil
+	case WS_OPCODE_BINARY:
+		if c.fragmented {
+			return c.fail(WS_CLOSE_PROTOCOL_ERROR, "message not finished")
+		}
+		c.fragmented, c.remaining = !fin, length
+		return nil
+	case WS_OPCODE_TEXT:
+		c.writeClose(WS_CLOSE_UNSUPPORTED)
+		return ErrWebSocketText
+	case WS_OPCODE_CLOSE, WS_OPCODE_PING, WS_OPCODE_PONG:
+	default:
+		return c.fail(WS_CLOSE_PROTOCOL_ERROR, fmt.Sprintf("opcode %v not supported", opcode))
+	}
+	if !fin {
+		return c.fail(WS_CLOSE_PROTOCOL_ERROR, "control frame fragmented")
+	}
+	if length > 125 {
+		return c.fail(WS_CLOSE_PROTOCOL_ERROR, "control frame too long")
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return err
+	}
+	c.unmask(payload)
+	switch opcode {
+	case WS_OPCODE_CLOSE:
+		c.sendClose(payload)
+		return io.EOF
+	case WS_OPCODE_PING:
+		return c.writeFrame(WS_OPCODE_PONG, payload)
+	}
+	return nil
+}
+
+// fail sends close frame with code and returns protocol error
+func (c *wsConn) fail(code uint16, reason string) error {
+	c.writeClose(code)
+	return fmt.Errorf("%w: %v", ErrWebSocketProtocol, reason)
+}
+
+func (c *wsConn) writeClose(code uint16) error {
+	return c.sendClose([]byte{byte(code >> 8), byte(code)})
+}
+
+// sendClose writes close frame if it was not sent
+func (c *wsConn) sendClose(payload []byte) error {
+	if err := c.writeFrame(WS_OPCODE_CLOSE, payload); err != net.ErrClosed {
+		return err
+	}
+	return nil
+}
+
+func (c *wsConn) Write(p []byte) (int, error) {
+	if err := c.writeFrame(WS_OPCODE_BINARY, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// writeFrame writes frame, no frame is written after close frame
+func (c *wsConn) writeFrame(opcode byte, payload []byte) error {
+	frame := make([]byte, 0, len(payload)+14)
+	frame = append(frame, 0x80|opcode)
+	var maskBit byte
+	if c.client {
+		maskBit = 0x80
+	}
+	switch {
+	case len(payload) < 126:
+		frame = append(frame, maskBit|byte(len(payload)))
+	case len(payload) <= 0xffff:
+		frame = append(frame, maskBit|126, byte(len(payload)>>8), byte(len(payload)))
+	default:
+		frame = append(frame, maskBit|127)
+		var ext [8]byte
+		binary.BigEndian.PutUint64(ext[:], uint64(len(payload)))
+		frame = append(frame, ext[:]...)
+	}
+	if c.client {
+		var mask [4]byte
+		if _, err := io.ReadFull(rand.Reader, mask[:]); err != nil {
+			return err
+		}
+		frame = append(frame, mask[:]...)
+		for i, b := range payload {
+			frame = append(frame, b^mask[i%4])
+		}
+	} else {
+		frame = append(frame, payload...)
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.closed {
+		return net.ErrClosed
+	}
+	c.closed = opcode == WS_OPCODE_CLOSE
+	_, err := c.Conn.Write(frame)
+	return err
+}
+
+// Close sends normal closure if no close frame was sent and closes connection
+func (c *wsConn) Close() error {
+	c.writeClose(WS_CLOSE_NORMAL)
+	return c.Conn.Close()
+}
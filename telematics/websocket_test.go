@@ -0,0 +1,137 @@
+package telematics
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boiledgas/protocol/telematics/section"
+)
+
+func Test_WebSocket(t *testing.T) {
+	types := make(chan section.DeviceType, 1)
+	srv := Server{Handler: HandlerFunc(func(s *Session, r *Request) Response {
+		types <- s.Identification.Type
+		return Response{Flags: RESPONSE_OK}
+	})}
+	ts := httptest.NewServer(&WebSocketHandler{Server: &srv})
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("plain request status wrong: %v", resp.Status)
+	}
+	req, _ := http.NewRequest(http.MethodGet, ts.URL, nil)
+	req.Header = http.Header{"Connection": {"Upgrade"}, "Upgrade": {"websocket"}, "Sec-Websocket-Version": {"13"}, "Sec-Websocket-Key": {"a2V5"}, "Origin": {"http://example.com"}}
+	if resp, err = http.DefaultClient.Do(req); err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("request of other origin status wrong: %v", resp.Status)
+	}
+
+	client, err := DialWebSocket("ws"+strings.TrimPrefix(ts.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	client.Timeout = time.Second
+	hello := Request{Sequence: Sequence(), Id: section.Identification{CodeText: "app1", Type: section.DEVICETYPE_APPLICATION}}
+	hello.Id.Set(section.IDENTIFICATION_FLAGS_CODETEXT, true)
+	hello.Id.Set(section.IDENTIFICATION_FLAGS_DEVICETYPE, true)
+	hello.Set(section.FLAG_IDENTIFICATION, true)
+	for i := 0; i < 2; i++ {
+		hello.Sequence = Sequence()
+		r, err := client.Send(&hello)
+		if err != nil || r.Sequence != hello.Sequence || r.Flags != RESPONSE_OK {
+			t.Fatalf("response wrong: %v %v", r, err)
+		}
+		if deviceType := <-types; deviceType != section.DEVICETYPE_APPLICATION {
+			t.Errorf("device type wrong: %v", deviceType)
+		}
+	}
+
+	ws := client.conn.(*wsConn)
+	if err := ws.writeFrame(WS_OPCODE_PING, []byte("ping")); err != nil {
+		t.Fatal(err)
+	}
+	hello.Sequence = Sequence()
+	if r, err := client.Send(&hello); err != nil || r.Sequence != hello.Sequence {
+		t.Errorf("response after ping wrong: %v %v", r, err)
+	}
+}
+
+// serveFrames writes frames of client to server connection and returns data read by server
+// with its error and frames written by server
+func serveFrames(frames ...[]byte) (data []byte, err error, written []byte) {
+	server, client := net.Pipe()
+	ws := &wsConn{Conn: server, reader: bufio.NewReader(server)}
+	out := make(chan []byte)
+	go func() {
+		b, _ := io.ReadAll(client)
+		out <- b
+	}()
+	go func() {
+		for _, f := range frames {
+			if _, err := client.Write(f); err != nil {
+				return
+			}
+		}
+	}()
+	data, err = io.ReadAll(ws)
+	ws.Close()
+	written = <-out
+	client.Close()
+	return
+}
+
+// maskedFrame returns frame of client with first header byte b and zero mask
+func maskedFrame(b byte, payload string) []byte {
+	return append([]byte{b, 0x80 | byte(len(payload)), 0, 0, 0, 0}, payload...)
+}
+
+func Test_WebSocketFrames(t *testing.T) {
+	data, err, written := serveFrames(
+		maskedFrame(WS_OPCODE_BINARY, "ab"),
+		maskedFrame(0x80|WS_OPCODE_PING, "p"),
+		maskedFrame(WS_OPCODE_CONTINUATION, "c"),
+		maskedFrame(0x80|WS_OPCODE_CONTINUATION, "d"),
+		maskedFrame(0x80|WS_OPCODE_CLOSE, "\x03\xe8"),
+	)
+	if string(data) != "abcd" || err != nil {
+		t.Errorf("fragmented message wrong: %q %v", data, err)
+	}
+	if expected := []byte{0x8a, 0x01, 'p', 0x88, 0x02, 0x03, 0xe8}; !bytes.Equal(written, expected) {
+		t.Errorf("pong and single close expected: %x", written)
+	}
+
+	for name, frames := range map[string][][]byte{
+		"continuation":    {maskedFrame(0x80|WS_OPCODE_CONTINUATION, "a")},
+		"interleaved":     {maskedFrame(WS_OPCODE_BINARY, "a"), maskedFrame(0x80|WS_OPCODE_BINARY, "b")},
+		"fragmented ping": {maskedFrame(WS_OPCODE_PING, "p")},
+		"reserved bits":   {maskedFrame(0xc0|WS_OPCODE_BINARY, "a")},
+		"unknown opcode":  {maskedFrame(0x83, "a")},
+		"not masked":      {{0x80 | WS_OPCODE_BINARY, 0x01, 'a'}},
+		"long control":    {append([]byte{0x80 | WS_OPCODE_PING, 0x80 | 126, 0, 126, 0, 0, 0, 0}, make([]byte, 126)...)},
+	} {
+		_, err, written := serveFrames(frames...)
+		if !errors.Is(err, ErrWebSocketProtocol) {
+			t.Errorf("%v: error wrong: %v", name, err)
+		}
+		if !bytes.Equal(written, []byte{0x88, 0x02, 0x03, 0xea}) {
+			t.Errorf("%v: protocol error close expected: %x", name, written)
+		}
+	}
+}
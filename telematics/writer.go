@@ -2,22 +2,26 @@ package telematics
 
 import (
 	"encoding/binary"
+	"fmt"
 	"io"
 	"github.com/boiledgas/protocol/telematics/value"
 	"github.com/boiledgas/protocol/utils"
-	"strconv"
+	"math"
 )
 
 type TelematicsWriter struct {
 	Writer        io.Writer
 	Checksum      utils.Checksum
 	Configuration *Configuration
+	Metrics       Metrics // receives counters of packets written, nil disables instrumentation
+	counter       *byteCounter
 }
 
 func NewWriter(w io.Writer) *TelematicsWriter {
-	checksum := utils.Checksum{Table: utils.CRC8[:]}
-	writer := io.MultiWriter(w, &checksum)
-	return &TelematicsWriter{Writer: writer, Checksum: checksum}
+	counter := &byteCounter{writer: w}
+	writer := &TelematicsWriter{Checksum: utils.Checksum{Table: utils.CRC8[:]}, counter: counter}
+	writer.Writer = io.MultiWriter(counter, &writer.Checksum)
+	return writer
 }
 
 func (w *TelematicsWriter) WriteBool(v bool) {
@@ -61,7 +65,45 @@ func (w *TelematicsWriter) WriteString(s string) {
 	binary.Write(w.Writer, binary.LittleEndian, []byte(s))
 }
 
+// FixedPointError is returned by WriteData and WriteRequest for value not representable by its wire format
+type FixedPointError struct {
+	Value float64
+	Err   error // utils.ErrNotFinite or utils.ErrOverflow
+}
+
+func (e *FixedPointError) Error() string {
+	return fmt.Sprintf("fixed-point %v: %v", e.Value, e.Err)
+}
+
+func (e *FixedPointError) Unwrap() error {
+	return e.Err
+}
+
+// fixed encodes v with f, unrepresentable value panics with FixedPointError
+func (w *TelematicsWriter) fixed(f utils.FixedPoint, v float64) int64 {
+	res, err := f.Encode(v)
+	if err != nil {
+		panic(&FixedPointError{Value: v, Err: err})
+	}
+	return res
+}
+
+// recoverWrite returns FixedPointError raised by writer helpers, other panics are not recovered
+func recoverWrite(err *error) {
+	if rec := recover(); rec != nil {
+		e, ok := rec.(*FixedPointError)
+		if !ok {
+			panic(rec)
+		}
+		*err = e
+	}
+}
+
 func (w *TelematicsWriter) WriteCommon(v *value.Common) {
+	w.writeCommon(v, 1.0, 1.0)
+}
+
+func (w *TelematicsWriter) writeCommon(v *value.Common, valueScale float64, meterScale float64) {
 	binary.Write(w.Writer, binary.LittleEndian, v.Flags8)
 	if v.Has(value.COMMON_FLAG_STATE) {
 		w.WriteBool(v.State)
@@ -70,10 +112,12 @@ func (w *TelematicsWriter) WriteCommon(v *value.Common) {
 		binary.Write(w.Writer, binary.LittleEndian, v.Percentage)
 	}
 	if v.Has(value.COMMON_FLAG_VALUE) {
-		binary.Write(w.Writer, binary.LittleEndian, int32(v.Value))
+		f := utils.FixedPoint{Scale: valueScale, Min: math.MinInt32, Max: math.MaxInt32}
+		binary.Write(w.Writer, binary.LittleEndian, int32(w.fixed(f, v.Value)))
 	}
 	if v.Has(value.COMMON_FLAG_METER) {
-		binary.Write(w.Writer, binary.LittleEndian, uint32(v.Meter))
+		f := utils.FixedPoint{Scale: meterScale, Min: 0, Max: math.MaxUint32}
+		binary.Write(w.Writer, binary.LittleEndian, uint32(w.fixed(f, v.Meter)))
 	}
 }
 
@@ -83,7 +127,7 @@ func (w *TelematicsWriter) WriteNameValue(v *value.NameValue, dataType value.Dat
 	}
 
 	w.WriteString(v.Name)
-	w.WriteData(v.Value, dataType)
+	w.writeData(v.Value, dataType)
 }
 
 func (w *TelematicsWriter) WriteNameList(list []value.NameValue, dataType value.DataType) {
@@ -96,8 +140,8 @@ func (w *TelematicsWriter) WriteNameList(list []value.NameValue, dataType value.
 func (w *TelematicsWriter) WriteGps(v *value.Gps) {
 	binary.Write(w.Writer, binary.LittleEndian, v.Flags8)
 	if lat, lng, ok := v.Latitude, v.Longitude, v.Has(value.GPS_FLAG_LATLNG); ok {
-		latVal := int32(lat * 10000000)
-		lngVal := int32(lng * 10000000)
+		latVal := int32(w.fixed(fixedCoordinate, lat))
+		lngVal := int32(w.fixed(fixedCoordinate, lng))
 		binary.Write(w.Writer, binary.LittleEndian, latVal)
 		binary.Write(w.Writer, binary.LittleEndian, lngVal)
 	}
@@ -114,32 +158,89 @@ func (w *TelematicsWriter) WriteGps(v *value.Gps) {
 	if sat, ok := v.Sat, v.Has(value.GPS_FLAG_SATELLITES); ok {
 		binary.Write(w.Writer, binary.LittleEndian, sat)
 	}
+	if v.Has(value.GPS_FLAG_DOP) {
+		binary.Write(w.Writer, binary.LittleEndian, uint16(w.fixed(fixedGpsPrecise, float64(v.Hdop))))
+		binary.Write(w.Writer, binary.LittleEndian, uint16(w.fixed(fixedGpsPrecise, float64(v.Pdop))))
+	}
+	if v.Has(value.GPS_FLAG_FIX) {
+		binary.Write(w.Writer, binary.LittleEndian, v.Fix)
+		w.WriteBool(v.Valid)
+		binary.Write(w.Writer, binary.LittleEndian, v.Constellation)
+	}
+	if v.Has(value.GPS_FLAG_EXTENDED) {
+		binary.Write(w.Writer, binary.LittleEndian, v.Extended)
+		if v.Extended.Has(value.GPS_EXTFLAG_SPEED) {
+			binary.Write(w.Writer, binary.LittleEndian, uint16(w.fixed(fixedGpsPrecise, float64(v.PreciseSpeed))))
+		}
+		if v.Extended.Has(value.GPS_EXTFLAG_COURSE) {
+			binary.Write(w.Writer, binary.LittleEndian, uint16(w.fixed(fixedGpsPrecise, float64(v.PreciseCourse))))
+		}
+	}
 }
 
 func (w *TelematicsWriter) WriteGsm(v *value.Gsm) {
-	mm := v.MCC + v.MNC
-	mcc_mnc, err := strconv.ParseUint(mm, 10, 32)
+	mcc_mnc, err := value.MccMnc(v.MCC, v.MNC)
 	if err != nil {
 		mcc_mnc = 0
 	}
-	w.WriteUInt24(uint32(mcc_mnc))
+	w.WriteUInt24(mcc_mnc)
 	binary.Write(w.Writer, binary.LittleEndian, v.LAC)
 	binary.Write(w.Writer, binary.LittleEndian, v.CID)
 	binary.Write(w.Writer, binary.LittleEndian, v.Signal)
 }
 
+func (w *TelematicsWriter) WriteCellInfo(v *value.CellInfo) {
+	mcc_mnc, err := value.MccMnc(v.MCC, v.MNC)
+	if err != nil {
+		mcc_mnc = 0
+	}
+	w.WriteUInt24(mcc_mnc)
+	w.WriteCell(&v.Serving)
+	binary.Write(w.Writer, binary.LittleEndian, byte(len(v.Neighbors)))
+	for i := range v.Neighbors {
+		w.WriteCell(&v.Neighbors[i])
+	}
+}
+
+func (w *TelematicsWriter) WriteCell(v *value.Cell) {
+	binary.Write(w.Writer, binary.LittleEndian, v.Radio)
+	if v.Radio.HasTAC() {
+		binary.Write(w.Writer, binary.LittleEndian, v.TAC)
+	} else {
+		binary.Write(w.Writer, binary.LittleEndian, v.LAC)
+	}
+	binary.Write(w.Writer, binary.LittleEndian, v.CID)
+	binary.Write(w.Writer, binary.LittleEndian, v.Signal)
+}
+
 func (w *TelematicsWriter) WriteAcceleration(v *value.Acceleration) {
 	binary.Write(w.Writer, binary.LittleEndian, v.Flags8)
-	if x, ok := v.AxisX, v.Has(value.ACCELERATION_FLAG_X); ok {
-		binary.Write(w.Writer, binary.LittleEndian, int16(x*1000.0))
+	if v.Has(value.ACCELERATION_FLAG_X) {
+		binary.Write(w.Writer, binary.LittleEndian, int16(w.fixed(fixedAxis, float64(v.AxisX))))
+	}
+	if v.Has(value.ACCELERATION_FLAG_Y) {
+		binary.Write(w.Writer, binary.LittleEndian, int16(w.fixed(fixedAxis, float64(v.AxisY))))
+	}
+	if v.Has(value.ACCELERATION_FLAG_Z) {
+		binary.Write(w.Writer, binary.LittleEndian, int16(w.fixed(fixedAxis, float64(v.AxisZ))))
 	}
-	if y, ok := v.AxisY, v.Has(value.ACCELERATION_FLAG_Y); ok {
-		binary.Write(w.Writer, binary.LittleEndian, int16(y*1000.0))
+	if v.Has(value.ACCELERATION_FLAG_DURATION) {
+		binary.Write(w.Writer, binary.LittleEndian, v.Duration)
 	}
-	if z, ok := v.AxisZ, v.Has(value.ACCELERATION_FLAG_Z); ok {
-		binary.Write(w.Writer, binary.LittleEndian, int16(z*1000.0))
+	if v.Has(value.ACCELERATION_FLAG_GYRO_X) {
+		binary.Write(w.Writer, binary.LittleEndian, int16(w.fixed(fixedGyro, float64(v.GyroX))))
 	}
-	binary.Write(w.Writer, binary.LittleEndian, v.Duration)
+	if v.Has(value.ACCELERATION_FLAG_GYRO_Y) {
+		binary.Write(w.Writer, binary.LittleEndian, int16(w.fixed(fixedGyro, float64(v.GyroY))))
+	}
+	if v.Has(value.ACCELERATION_FLAG_GYRO_Z) {
+		binary.Write(w.Writer, binary.LittleEndian, int16(w.fixed(fixedGyro, float64(v.GyroZ))))
+	}
+}
+
+func (w *TelematicsWriter) WriteIoPort(v *value.IoPort) {
+	binary.Write(w.Writer, binary.LittleEndian, v.Flags)
+	binary.Write(w.Writer, binary.LittleEndian, v.State)
 }
 
 func (w *TelematicsWriter) WriteRgb(v *value.Rgb) {
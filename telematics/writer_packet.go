@@ -8,14 +8,32 @@ import (
 )
 
 func (w *TelematicsWriter) WriteResponse(p *Response) (err error) {
+	start := w.written()
 	//TODO: if check for error: short write
 	binary.Write(w.Writer, binary.LittleEndian, []byte{PACKET_TYPE_RESPONSE, p.Sequence, byte(p.Flags)})
+	if p.Has(RESPONSE_CHALLENGE) {
+		w.WriteBytes(p.Nonce)
+	}
 	p.Crc = w.Checksum.Compute()
 	binary.Write(w.Writer, binary.LittleEndian, p.Crc)
+	w.Checksum.Compute() // crc byte is not part of next packet
+	if w.Metrics != nil {
+		measureResponse(w.Metrics, DIRECTION_OUT, w.written()-start)
+	}
 	return
 }
 
+// WriteRequest writes request, values not representable by their data type are returned as error,
+// part of packet is already written then and checksum is reset, request should be written to buffer
+// before it is sent to connection
 func (w *TelematicsWriter) WriteRequest(p *Request) (err error) {
+	defer func() {
+		if err != nil {
+			w.Checksum.Compute()
+		}
+	}()
+	defer recoverWrite(&err)
+	start := w.written()
 	//TODO: if check for error: short write
 	binary.Write(w.Writer, binary.LittleEndian, []byte{PACKET_TYPE_REQUEST, byte(0x02), p.Sequence})
 	binary.Write(w.Writer, binary.LittleEndian, p.Timestamp)
@@ -81,6 +99,10 @@ func (w *TelematicsWriter) WriteRequest(p *Request) (err error) {
 	binary.Write(w.Writer, binary.LittleEndian, section.SECTION_ENDOFPAYLOAD)
 	crc := w.Checksum.Compute()
 	binary.Write(w.Writer, binary.LittleEndian, crc)
+	w.Checksum.Compute() // crc byte is not part of next packet
+	if w.Metrics != nil {
+		measureRequest(w.Metrics, DIRECTION_OUT, p, w.written()-start)
+	}
 	return
 }
 
@@ -115,6 +137,12 @@ func (w *TelematicsWriter) WriteAuthentication(s *section.Authentication) {
 	if s.Has(section.AUTHENTICATION_FLAGS_SECRET) {
 		w.WriteBytes(s.Secret)
 	}
+	if s.Has(section.AUTHENTICATION_FLAGS_NONCE) {
+		w.WriteBytes(s.Nonce)
+	}
+	if s.Has(section.AUTHENTICATION_FLAGS_DIGEST) {
+		w.WriteBytes(s.Digest)
+	}
 }
 
 func (w *TelematicsWriter) WriteModule(s *section.Module) {
@@ -135,10 +163,10 @@ func (w *TelematicsWriter) WriteModuleProperty(s *section.ModuleProperty) {
 	binary.Write(w.Writer, binary.LittleEndian, s.Type)
 
 	if s.Has(section.MODULE_PROPERTY_FLAGS_MIN) {
-		w.WriteData(s.Min, s.Type)
+		w.writeData(s.Min, s.Type)
 	}
 	if s.Has(section.MODULE_PROPERTY_FLAGS_MAX) {
-		w.WriteData(s.Max, s.Type)
+		w.writeData(s.Max, s.Type)
 	}
 	if s.Has(section.MODULE_PROPERTY_FLAGS_LIST) {
 		w.WriteNameList(s.List, s.Type)
@@ -168,15 +196,15 @@ func (w *TelematicsWriter) WriteModulePropertyValue(s *section.ModulePropertyVal
 		}
 
 		binary.Write(w.Writer, binary.LittleEndian, id)
-		w.WriteData(v, p.Type)
+		w.writeData(v, p.Type)
 	}
 }
 
 func (w *TelematicsWriter) WriteModulePropertyDisable(s *section.ModulePropertyDisable) {
 	binary.Write(w.Writer, binary.LittleEndian, byte(len(s.DisabledProperties)))
-	for id, v := range s.DisabledProperties {
-		binary.Write(w.Writer, binary.LittleEndian, id)
-		w.WriteData(v, value.Byte)
+	for _, p := range s.Properties() {
+		binary.Write(w.Writer, binary.LittleEndian, p.ModuleId)
+		w.writeData(p.PropertyId, value.Byte)
 	}
 }
 
@@ -199,10 +227,10 @@ func (w *TelematicsWriter) WriteCommandArgument(s *section.CommandArgument) {
 	binary.Write(w.Writer, binary.LittleEndian, s.Id)
 	binary.Write(w.Writer, binary.LittleEndian, s.Type)
 	if s.Has(section.COMMAND_ARGUMENT_FLAGS_MIN) {
-		w.WriteData(s.Min, s.Type)
+		w.writeData(s.Min, s.Type)
 	}
 	if s.Has(section.COMMAND_ARGUMENT_FLAGS_MAX) {
-		w.WriteData(s.Max, s.Type)
+		w.writeData(s.Max, s.Type)
 	}
 	if s.Has(section.COMMAND_ARGUMENT_FLAGS_LIST) {
 		w.WriteNameList(s.List, s.Type)
@@ -227,7 +255,7 @@ func (w *TelematicsWriter) WriteCommandExecute(s *section.CommandExecute) {
 	for id, v := range s.Arguments {
 		if w.Configuration.GetArgument(s.ModuleId, s.CommandId, id, &arg) {
 			binary.Write(w.Writer, binary.LittleEndian, id)
-			w.WriteData(v, arg.Type)
+			w.writeData(v, arg.Type)
 		} else {
 			panic("argument not found")
 		}
@@ -28,7 +28,7 @@ func (c *Checksum) Write(p []byte) (n int, err error) {
 	for _, b := range p {
 		c.crc = c.Table[c.crc^b]
 	}
-	return
+	return len(p), nil
 }
 
 func (c *Checksum) Compute() byte {
@@ -1,5 +1,7 @@
 package utils
 
+import "fmt"
+
 type Flags8 uint8
 type Flags16 uint16
 
@@ -46,3 +48,56 @@ func (f *Flags16) Load(flags *[16]uint16) {
 		}
 	}
 }
+
+// flag name for text encodings
+type FlagName struct {
+	Flag uint16
+	Name string
+}
+
+func (f *Flags8) Named(names []FlagName) map[string]bool {
+	named := make(map[string]bool, len(names))
+	for _, n := range names {
+		named[n.Name] = f.Has(uint8(n.Flag))
+	}
+	return named
+}
+
+func (f *Flags8) SetNamed(names []FlagName, named map[string]bool) error {
+	for name, value := range named {
+		flag, ok := findFlag(names, name)
+		if !ok {
+			return fmt.Errorf("unknown flag %v", name)
+		}
+		f.Set(uint8(flag), value)
+	}
+	return nil
+}
+
+func (f *Flags16) Named(names []FlagName) map[string]bool {
+	named := make(map[string]bool, len(names))
+	for _, n := range names {
+		named[n.Name] = f.Has(n.Flag)
+	}
+	return named
+}
+
+func (f *Flags16) SetNamed(names []FlagName, named map[string]bool) error {
+	for name, value := range named {
+		flag, ok := findFlag(names, name)
+		if !ok {
+			return fmt.Errorf("unknown flag %v", name)
+		}
+		f.Set(flag, value)
+	}
+	return nil
+}
+
+func findFlag(names []FlagName, name string) (uint16, bool) {
+	for _, n := range names {
+		if n.Name == name {
+			return n.Flag, true
+		}
+	}
+	return 0, false
+}
@@ -1,6 +1,14 @@
 package utils
 
-import "math"
+import (
+	"errors"
+	"math"
+)
+
+var (
+	ErrNotFinite = errors.New("value is NaN or infinite")
+	ErrOverflow  = errors.New("value out of range")
+)
 
 func Round(input float64) float64 {
 	if input < 0 {
@@ -8,3 +16,21 @@ func Round(input float64) float64 {
 	}
 	return math.Floor(input + 0.5)
 }
+
+// Fixed-point encoding of scaled float values: v*Scale rounded half away from zero into [Min, Max]
+type FixedPoint struct {
+	Scale float64
+	Min   int64
+	Max   int64
+}
+
+func (f FixedPoint) Encode(v float64) (int64, error) {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, ErrNotFinite
+	}
+	scaled := Round(v * f.Scale)
+	if scaled < float64(f.Min) || scaled > float64(f.Max) {
+		return 0, ErrOverflow
+	}
+	return int64(scaled), nil
+}